@@ -7,6 +7,7 @@ CREATE TABLE IF NOT EXISTS `user` (
   `username` VARCHAR(255) NOT NULL DEFAULT '',
   `password` VARCHAR(255) NOT NULL DEFAULT '',
   `display_name` VARCHAR(255) NOT NULL DEFAULT '',
+  `avatar` VARCHAR(255) COMMENT 'Object storage key of the avatar',
 
   -- Utility columns
   `status` SMALLINT NOT NULL DEFAULT '1',
@@ -40,3 +41,48 @@ CREATE TABLE IF NOT EXISTS `role` (
     `deleted_by` VARCHAR(255),
     PRIMARY KEY (`id`)
 );
+
+-- [DDL] Create new table for Refresh Token
+-- status: 1 active, 0 already rotated, -1 revoked
+DROP TABLE IF EXISTS `refresh_token`;
+CREATE TABLE IF NOT EXISTS `refresh_token` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `fk_user_id` INT NOT NULL COMMENT 'Foreign Key To user Id',
+    `family_id` VARCHAR(64) NOT NULL COMMENT 'Shared by every token rotated from the same sign in',
+    `token_hash` VARCHAR(64) NOT NULL COMMENT 'SHA-256 of the opaque refresh token',
+    `expires_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+
+    -- Utility columns
+    `status` SMALLINT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` VARCHAR(255),
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` VARCHAR(255),
+    PRIMARY KEY (`id`),
+    UNIQUE (`token_hash`),
+    INDEX (`family_id`),
+    INDEX (`fk_user_id`)
+) ENGINE = INNODB COMMENT='Refresh token table';
+
+-- [DDL] Create new table for Verification Token
+DROP TABLE IF EXISTS `verification_token`;
+CREATE TABLE IF NOT EXISTS `verification_token` (
+    `id` INT NOT NULL AUTO_INCREMENT,
+    `fk_user_id` INT NOT NULL COMMENT 'Foreign Key To user Id',
+    `token_hash` VARCHAR(64) NOT NULL COMMENT 'SHA-256 of the opaque token sent by email',
+    `expires_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+
+    -- Utility columns
+    `status` SMALLINT NOT NULL DEFAULT '1',
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` VARCHAR(255),
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `updated_by` VARCHAR(255),
+    PRIMARY KEY (`id`),
+    UNIQUE (`token_hash`),
+    INDEX (`fk_user_id`)
+) ENGINE = INNODB COMMENT='Email verification token table';
@@ -5,14 +5,19 @@ import (
 
 	"github.com/adiatma85/exp-golang-graphql/src/business/domain"
 	"github.com/adiatma85/exp-golang-graphql/src/business/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/cache"
 	"github.com/adiatma85/exp-golang-graphql/src/business/handler"
 	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
 	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/exp-golang-graphql/utils/mailer"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
 	"github.com/adiatma85/own-go-sdk/configreader"
 	"github.com/adiatma85/own-go-sdk/instrument"
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
 	"github.com/adiatma85/own-go-sdk/log"
 	"github.com/adiatma85/own-go-sdk/parser"
+	"github.com/adiatma85/own-go-sdk/redis"
 	"github.com/adiatma85/own-go-sdk/sql"
 )
 
@@ -38,23 +43,43 @@ func main() {
 	// Init the DB
 	db := sql.Init(cfg.SQL, log, instr)
 
+	// Init the redis, it is only connected when a feature is configured to use it
+	var rds redis.Interface
+	if cfg.Gin.GraphQL.PersistedQuery.Cache == cache.TypeRedis ||
+		(cfg.Gin.GraphQL.ResponseCache.Enabled && cfg.Gin.GraphQL.ResponseCache.Cache == cache.TypeRedis) {
+		rds = redis.Init(cfg.Redis, log)
+	}
+
 	// init the parser
 	parsers := parser.InitParser(log, cfg.Parser)
 
 	// Init the jwt
 	jwt := jwtAuth.Init(cfg.JwtAuth)
 
+	// Init the pubsub, it delivers the events published to graphql subscriptions
+	ps := pubsub.Init(pubsub.InitParam{Conf: cfg.PubSub, Log: log, Redis: cfg.Redis})
+
+	// Init the object storage, it keeps uploaded files like avatars
+	store := storage.Init(storage.InitParam{Conf: cfg.Storage, Log: log})
+
+	// Init the mailer, it sends the account emails like the email verification
+	mail := mailer.Init(mailer.InitParam{Conf: cfg.Mailer, Log: log})
+
 	// Init the domain
 	d := domain.Init(domain.InitParam{Log: log, Db: db, Json: parsers.JSONParser()})
 
 	// Init the usecase
-	uc := usecase.Init(usecase.InitParam{Log: log, Dom: d, JwtAuth: jwt})
+	uc := usecase.Init(usecase.InitParam{Log: log, Dom: d, JwtAuth: jwt, JwtAuthConf: cfg.JwtAuth, PubSub: ps, Storage: store, Mailer: mail, EmailVerificationConf: cfg.EmailVerification})
 
 	// Initialize the Graphql in here
-	graphql := graphql.NewExecutableSchema(graphql.Config{Resolvers: &graphql.Resolver{Uc: uc, Log: log}})
+	graphql := graphql.NewExecutableSchema(graphql.Config{
+		Resolvers:  &graphql.Resolver{Uc: uc, Log: log, PubSub: ps, Storage: store},
+		Directives: graphql.NewDirectiveRoot(uc, jwt),
+		Complexity: graphql.NewComplexityRoot(),
+	})
 
 	// Init the GIN
-	rest := handler.Init(handler.InitParam{Conf: cfg.Gin, Json: parsers.JSONParser(), Log: log, Uc: uc, Instrument: instr, JwtAuth: jwt, Graphql: graphql})
+	rest := handler.Init(handler.InitParam{Conf: cfg.Gin, Json: parsers.JSONParser(), Log: log, Uc: uc, Instrument: instr, JwtAuth: jwt, Graphql: graphql, Redis: rds, Storage: cfg.Storage})
 
 	rest.Run()
 }
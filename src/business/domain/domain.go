@@ -1,16 +1,21 @@
 package domain
 
 import (
+	"github.com/adiatma85/exp-golang-graphql/src/business/domain/refreshtoken"
 	"github.com/adiatma85/exp-golang-graphql/src/business/domain/role"
 	"github.com/adiatma85/exp-golang-graphql/src/business/domain/user"
+	"github.com/adiatma85/exp-golang-graphql/src/business/domain/verificationtoken"
 	"github.com/adiatma85/own-go-sdk/log"
 	"github.com/adiatma85/own-go-sdk/parser"
 	"github.com/adiatma85/own-go-sdk/sql"
 )
 
 type Domain struct {
-	User user.Interface
-	Role role.Interface
+	User         user.Interface
+	Role         role.Interface
+	RefreshToken refreshtoken.Interface
+	// VerificationToken is issued on registration to verify the email
+	VerificationToken verificationtoken.Interface
 }
 
 type InitParam struct {
@@ -21,8 +26,10 @@ type InitParam struct {
 
 func Init(param InitParam) *Domain {
 	domain := &Domain{
-		User: user.Init(user.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
-		Role: role.Init(role.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
+		User:              user.Init(user.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
+		Role:              role.Init(role.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
+		RefreshToken:      refreshtoken.Init(refreshtoken.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
+		VerificationToken: verificationtoken.Init(verificationtoken.InitParam{Log: param.Log, Db: param.Db, Json: param.Json}),
 	}
 
 	return domain
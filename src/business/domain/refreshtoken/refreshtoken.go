@@ -0,0 +1,72 @@
+package refreshtoken
+
+import (
+	"context"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/parser"
+	"github.com/adiatma85/own-go-sdk/sql"
+)
+
+type Interface interface {
+	Create(ctx context.Context, insertParam entity.CreateRefreshTokenParam) (entity.RefreshToken, error)
+	Get(ctx context.Context, params entity.RefreshTokenParam) (entity.RefreshToken, error)
+	Update(ctx context.Context, updateParam entity.UpdateRefreshTokenParam, selectParam entity.RefreshTokenParam) error
+}
+
+type InitParam struct {
+	Log  log.Interface
+	Db   sql.Interface
+	Json parser.JSONInterface
+}
+
+type refreshToken struct {
+	log  log.Interface
+	db   sql.Interface
+	json parser.JSONInterface
+}
+
+func Init(param InitParam) Interface {
+	r := &refreshToken{
+		log:  param.Log,
+		db:   param.Db,
+		json: param.Json,
+	}
+
+	return r
+}
+
+func (r *refreshToken) Create(ctx context.Context, insertParam entity.CreateRefreshTokenParam) (entity.RefreshToken, error) {
+	result := entity.RefreshToken{}
+
+	tx, err := r.db.Leader().BeginTx(ctx, "txcRefreshToken", sql.TxOptions{})
+	if err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	tx, result, err = r.createSQLRefreshToken(tx, insertParam)
+	if err != nil {
+		return result, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return r.Get(ctx, entity.RefreshTokenParam{
+		ID: null.Int64From(result.ID),
+	})
+}
+
+func (r *refreshToken) Get(ctx context.Context, params entity.RefreshTokenParam) (entity.RefreshToken, error) {
+	return r.getSQLRefreshToken(ctx, params)
+}
+
+func (r *refreshToken) Update(ctx context.Context, updateParam entity.UpdateRefreshTokenParam, selectParam entity.RefreshTokenParam) error {
+	return r.updateSQLRefreshToken(ctx, updateParam, selectParam)
+}
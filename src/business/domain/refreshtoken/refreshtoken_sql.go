@@ -0,0 +1,86 @@
+package refreshtoken
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/query"
+	"github.com/adiatma85/own-go-sdk/sql"
+)
+
+func (r *refreshToken) createSQLRefreshToken(tx sql.CommandTx, v entity.CreateRefreshTokenParam) (sql.CommandTx, entity.RefreshToken, error) {
+	refreshToken := entity.RefreshToken{}
+
+	res, err := tx.NamedExec("iCreateRefreshToken", createRefreshToken, v)
+	if err != nil {
+		return tx, refreshToken, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil || rowCount < 1 {
+		return tx, refreshToken, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no rows affected")
+	}
+
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return tx, refreshToken, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	}
+
+	refreshToken.ID = lastID
+
+	return tx, refreshToken, nil
+}
+
+func (r *refreshToken) getSQLRefreshToken(ctx context.Context, params entity.RefreshTokenParam) (entity.RefreshToken, error) {
+	result := entity.RefreshToken{}
+
+	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &params.QueryOption)
+	queryExt, queryArgs, _, _, err := qb.Build(&params)
+	if err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	row, err := r.db.Follower().QueryRow(ctx, "rRefreshToken", readRefreshToken+queryExt, queryArgs...)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	} else if errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, err.Error())
+	}
+
+	if err := row.StructScan(&result); err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+	} else if errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, err.Error())
+	}
+
+	return result, nil
+}
+
+func (r *refreshToken) updateSQLRefreshToken(ctx context.Context, updateParam entity.UpdateRefreshTokenParam, selectParam entity.RefreshTokenParam) error {
+	r.log.Debug(ctx, fmt.Sprintf("update refresh token by: %v", selectParam))
+
+	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &selectParam.QueryOption)
+
+	var err error
+	queryUpdate, args, err := qb.BuildUpdate(&updateParam, &selectParam)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	res, err := r.db.Leader().Exec(ctx, "uRefreshToken", updateRefreshToken+queryUpdate, args...)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil || rowCount < 1 {
+		return errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no rows affected")
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("successfully updated refresh token: %v", updateParam))
+
+	return nil
+}
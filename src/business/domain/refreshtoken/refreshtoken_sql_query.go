@@ -0,0 +1,28 @@
+package refreshtoken
+
+const (
+	createRefreshToken = `
+	INSERT INTO refresh_token (fk_user_id, family_id, token_hash, expires_at, created_by)
+	    VALUES (:fk_user_id, :family_id, :token_hash, :expires_at, :created_by)`
+
+	readRefreshToken = `
+	SELECT
+	    id,
+	    fk_user_id,
+	    family_id,
+	    token_hash,
+	    expires_at,
+	    used_at,
+	    revoked_at,
+	    status,
+	    created_at,
+	    created_by,
+	    updated_at,
+	    updated_by
+	FROM
+	    refresh_token`
+
+	updateRefreshToken = `
+	UPDATE
+	    refresh_token`
+)
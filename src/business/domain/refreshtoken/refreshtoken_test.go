@@ -0,0 +1,448 @@
+package refreshtoken
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/query"
+	libsql "github.com/adiatma85/own-go-sdk/sql"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	mock_json "github.com/adiatma85/own-go-sdk/tests/mock/parser"
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/mock/gomock"
+)
+
+func Test_refreshToken_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
+
+	// Type in here
+	type args struct {
+		ctx         context.Context
+		createParam entity.CreateRefreshTokenParam
+	}
+
+	// Mock in here
+	mockCreateParam := entity.CreateRefreshTokenParam{
+		UserID:    1,
+		FamilyID:  "family",
+		TokenHash: "hash",
+		ExpiresAt: null.TimeFrom(time.Now()),
+	}
+
+	query := regexp.QuoteMeta(`INSERT INTO refresh_token (fk_user_id, family_id, token_hash, expires_at, created_by) VALUES (?, ?, ?, ?, ?)`)
+	queryGet := regexp.QuoteMeta(readRefreshToken)
+
+	// Test cases in here
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        entity.RefreshToken
+		wantErr     bool
+	}{
+		{
+			name: "cannot begin tx",
+			args: args{
+				ctx:         context.Background(),
+				createParam: mockCreateParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    entity.RefreshToken{},
+			wantErr: true,
+		},
+		{
+			name: "cannot exec refresh token",
+			args: args{
+				ctx:         context.Background(),
+				createParam: mockCreateParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+
+				sqlMock.ExpectExec(query).WillReturnError(errors.NewWithCode(codes.CodeSQL, "cannot create refresh token"))
+				sqlMock.ExpectRollback()
+
+				return sqlServer, err
+			},
+			want:    entity.RefreshToken{},
+			wantErr: true,
+		},
+		{
+			name: "refresh token no new row",
+			args: args{
+				ctx:         context.Background(),
+				createParam: mockCreateParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectRollback()
+				return sqlServer, err
+			},
+			want:    entity.RefreshToken{},
+			wantErr: true,
+		},
+		{
+			name: "cannot commit to the database",
+			args: args{
+				ctx:         context.Background(),
+				createParam: mockCreateParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+				sqlMock.ExpectCommit().WillReturnError(errors.NewWithCode(codes.CodeSQLTxCommit, "failed to commit"))
+				sqlMock.ExpectRollback()
+				return sqlServer, err
+			},
+			want: entity.RefreshToken{
+				ID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "all good",
+			args: args{
+				ctx:         context.Background(),
+				createParam: mockCreateParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+				sqlMock.ExpectCommit()
+
+				// Add new rows
+				row := sqlMock.NewRows([]string{
+					"id",
+					"fk_user_id",
+					"family_id",
+					"token_hash",
+				})
+				row.AddRow("1", "1", "family", "hash")
+				sqlMock.ExpectQuery(queryGet).WithArgs(1).WillReturnRows(row)
+
+				sqlMock.ExpectRollback()
+				return sqlServer, err
+			},
+			want: entity.RefreshToken{
+				ID:        1,
+				UserID:    1,
+				FamilyID:  "family",
+				TokenHash: "hash",
+			},
+			wantErr: false,
+		},
+	}
+
+	// Iterate the test in here
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+
+			sqlClient := libsql.Init(libsql.Config{
+				Driver: "sqlmock",
+				Leader: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+				Follower: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+			}, logger, nil)
+
+			// Initialize the Domain
+			domain := Init(InitParam{
+				Log:  logger,
+				Db:   sqlClient,
+				Json: mockJsonParser,
+			})
+
+			got, err := domain.Create(tt.args.ctx, tt.args.createParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("domain.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_refreshToken_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
+
+	// Type in here
+	type args struct {
+		ctx    context.Context
+		params entity.RefreshTokenParam
+	}
+
+	// Mock in here
+	now := time.Now()
+	query := regexp.QuoteMeta(readRefreshToken)
+
+	mockParam := entity.RefreshTokenParam{
+		TokenHash: null.StringFrom("hash"),
+	}
+
+	sampleResult := entity.RefreshToken{
+		ID:        1,
+		TokenHash: "hash",
+		ExpiresAt: null.TimeFrom(now),
+		Status:    entity.RefreshTokenStatusActive,
+	}
+
+	// Test cases in here
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        entity.RefreshToken
+		wantErr     bool
+	}{
+		{
+			name: "get empty row",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WithArgs("hash").WillReturnError(libsql.ErrNotFound)
+
+				return sqlServer, err
+			},
+			wantErr: true,
+			want:    entity.RefreshToken{},
+		},
+		{
+			name: "error struct scan",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "token_hash", "expires_at", "status"})
+				row.AddRow("A", "hash", now, 1)
+
+				sqlMock.ExpectQuery(query).WithArgs("hash").WillReturnRows(row)
+
+				return sqlServer, err
+			},
+			wantErr: true,
+			want:    entity.RefreshToken{},
+		},
+		{
+			name: "all good",
+			args: args{
+				ctx:    context.Background(),
+				params: mockParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "token_hash", "expires_at", "status"})
+				row.AddRow("1", "hash", now, 1)
+				sqlMock.ExpectQuery(query).WithArgs("hash").WillReturnRows(row)
+
+				return sqlServer, err
+			},
+			wantErr: false,
+			want:    sampleResult,
+		},
+	}
+
+	// Iterate the test in here
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+
+			sqlClient := libsql.Init(libsql.Config{
+				Driver: "sqlmock",
+				Leader: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+				Follower: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+			}, logger, nil)
+
+			// Initialize the Domain
+			domain := Init(InitParam{
+				Log:  logger,
+				Db:   sqlClient,
+				Json: mockJsonParser,
+			})
+
+			got, err := domain.Get(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("domain.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_refreshToken_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
+
+	// Type in here
+	type args struct {
+		ctx         context.Context
+		updateParam entity.UpdateRefreshTokenParam
+		selectParam entity.RefreshTokenParam
+	}
+
+	// Mock in here
+	queryUpdate := regexp.QuoteMeta("UPDATE refresh_token SET status=?, updated_by=? WHERE 1=1 AND status=1 AND family_id=?")
+
+	selectParamSample := entity.RefreshTokenParam{
+		FamilyID: null.StringFrom("family"),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	}
+
+	updateParamSample := entity.UpdateRefreshTokenParam{
+		Status:    null.Int64From(entity.RefreshTokenStatusRevoked),
+		UpdatedBy: null.StringFrom("1"),
+	}
+
+	// Test cases in here
+	tests := []struct {
+		name        string
+		prepSqlMock func() (*sql.DB, error)
+		args        args
+		wantErr     bool
+	}{
+		{
+			name: "failed to exec update",
+			args: args{
+				ctx:         context.Background(),
+				updateParam: updateParamSample,
+				selectParam: selectParamSample,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(queryUpdate).WillReturnError(assert.AnError)
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "update refresh token 0 rows affected",
+			args: args{
+				ctx:         context.Background(),
+				updateParam: updateParamSample,
+				selectParam: selectParamSample,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(queryUpdate).WillReturnResult(driver.RowsAffected(0))
+				return sqlServer, err
+			},
+			wantErr: true,
+		},
+		{
+			name: "update refresh token success",
+			args: args{
+				ctx:         context.Background(),
+				updateParam: updateParamSample,
+				selectParam: selectParamSample,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectExec(queryUpdate).WillReturnResult(driver.RowsAffected(1))
+				return sqlServer, err
+			},
+			wantErr: false,
+		},
+	}
+
+	// Iterate the test in here
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+
+			sqlClient := libsql.Init(libsql.Config{
+				Driver: "sqlmock",
+				Leader: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+				Follower: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+			}, logger, nil)
+
+			// Initialize the Domain
+			domain := Init(InitParam{
+				Log:  logger,
+				Db:   sqlClient,
+				Json: mockJsonParser,
+			})
+
+			err = domain.Update(tt.args.ctx, tt.args.updateParam, tt.args.selectParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
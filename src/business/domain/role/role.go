@@ -72,6 +72,10 @@ func (r *role) Get(ctx context.Context, params entity.RoleParam) (entity.Role, e
 }
 
 func (r *role) GetList(ctx context.Context, params entity.RoleParam) ([]entity.Role, *entity.Pagination, error) {
+	if params.UseCursor {
+		return r.getSQLRoleListByCursor(ctx, params)
+	}
+
 	return r.getSQLRoleList(ctx, params)
 }
 
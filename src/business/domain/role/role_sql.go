@@ -62,6 +62,10 @@ func (r *role) getSQLRole(ctx context.Context, params entity.RoleParam) (entity.
 func (r *role) getSQLRoleList(ctx context.Context, params entity.RoleParam) ([]entity.Role, *entity.Pagination, error) {
 	results := []entity.Role{}
 
+	if err := entity.ValidateSortBy(params.SortBy, entity.RoleSortColumns); err != nil {
+		return results, nil, err
+	}
+
 	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &params.QueryOption)
 	queryExt, queryArgs, countExt, countArgs, err := qb.Build(&params)
 	if err != nil {
@@ -101,6 +105,99 @@ func (r *role) getSQLRoleList(ctx context.Context, params entity.RoleParam) ([]e
 	return results, &pg, nil
 }
 
+func (r *role) getSQLRoleListByCursor(ctx context.Context, params entity.RoleParam) ([]entity.Role, *entity.Pagination, error) {
+	roles := []entity.Role{}
+
+	sortBy, desc, column, err := entity.ParseCursorSortBy(params.SortBy, entity.RoleSortColumns)
+	if err != nil {
+		return roles, nil, err
+	}
+
+	limit := entity.CursorLimit(params.Limit)
+
+	// the builder only renders the filters, ordering and limit are driven by the cursor
+	params.SortBy = nil
+	params.Page = 0
+	params.Limit = 0
+	params.QueryOption.DisableLimit = true
+
+	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &params.QueryOption)
+	queryExt, queryArgs, countExt, countArgs, err := qb.Build(&params)
+	if err != nil {
+		return roles, nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	filterExt, filterArgs := queryExt, queryArgs
+	queryExt, queryArgs, err = entity.BuildKeysetQuery(queryExt, queryArgs, params.After, sortBy, desc, column, limit)
+	if err != nil {
+		return roles, nil, err
+	}
+
+	rows, err := r.db.Follower().Query(ctx, "rListRoleByCursor", readRole+queryExt, queryArgs...)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return roles, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := entity.Role{}
+		if err := rows.StructScan(&temp); err != nil {
+			r.log.Error(ctx, errors.NewWithCode(codes.CodeSQLRowScan, err.Error()))
+			return roles, nil, err
+		}
+		roles = append(roles, temp)
+	}
+
+	pg := entity.Pagination{
+		SortBy: []string{sortBy},
+	}
+
+	// rows may have been deleted since the cursor was handed out, only a row up to the cursor means a previous page
+	if params.After != "" {
+		previousExt, previousArgs, err := entity.BuildKeysetPreviousQuery(filterExt, filterArgs, params.After, sortBy, desc, column)
+		if err != nil {
+			return roles, nil, err
+		}
+
+		var previous int64
+		if err := r.db.Follower().Get(ctx, "cRolePreviousByCursor", readRoleCount+previousExt, &previous, previousArgs...); err != nil {
+			return roles, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+		}
+		pg.HasPreviousPage = previous > 0
+	}
+
+	if int64(len(roles)) > limit {
+		roles = roles[:limit]
+		pg.HasNextPage = true
+	}
+
+	pg.CurrentElements = int64(len(roles))
+
+	if len(roles) > 0 {
+		start, err := roles[0].EncodeCursor(sortBy)
+		if err != nil {
+			return roles, nil, err
+		}
+
+		end, err := roles[len(roles)-1].EncodeCursor(sortBy)
+		if err != nil {
+			return roles, nil, err
+		}
+
+		pg.CursorStart = &start
+		pg.CursorEnd = &end
+	}
+
+	if params.IncludePagination {
+		if err := r.db.Follower().Get(ctx, "cRoleByCursor", readRoleCount+countExt, &pg.TotalElements, countArgs...); err != nil {
+			return roles, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+		}
+	}
+
+	return roles, &pg, nil
+}
+
 func (r *role) updateSQLRole(ctx context.Context, updateParam entity.UpdateRoleParam, selectParam entity.RoleParam) error {
 	r.log.Debug(ctx, fmt.Sprintf("update role by: %v", selectParam))
 
@@ -355,6 +355,9 @@ func Test_role_GetList(t *testing.T) {
 		},
 	}
 
+	mockInvalidSortParam := mockParams
+	mockInvalidSortParam.SortBy = []string{"-type; DROP TABLE role"}
+
 	mockPagination := entity.Pagination{
 		CurrentPage:     1,
 		CurrentElements: 1,
@@ -372,6 +375,20 @@ func Test_role_GetList(t *testing.T) {
 		want1       *entity.Pagination
 		wantErr     bool
 	}{
+		{
+			name: "unsupported sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockInvalidSortParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.Role{},
+			want1:   nil,
+			wantErr: true,
+		},
 		{
 			name: "error when query-ing",
 			args: args{
@@ -481,6 +498,221 @@ func Test_role_GetList(t *testing.T) {
 	}
 }
 
+func Test_role_GetListByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
+
+	// Type in here
+	type args struct {
+		ctx    context.Context
+		params entity.RoleParam
+	}
+
+	// Mock in here
+	now := time.Now()
+	queryExt := " WHERE 1=1 AND type=? AND (rank < ? OR (rank = ? AND id < ?)) ORDER BY rank DESC, id DESC LIMIT 2;"
+	query := regexp.QuoteMeta(readRole + queryExt)
+	queryCountExt := " WHERE 1=1 AND type=?;"
+	queryCount := regexp.QuoteMeta(readRoleCount + queryCountExt)
+	queryPreviousExt := " WHERE 1=1 AND type=? AND (rank > ? OR (rank = ? AND id >= ?));"
+	queryPrevious := regexp.QuoteMeta(readRoleCount + queryPreviousExt)
+
+	afterCursor := entity.Cursor{SortBy: "-rank", Value: "50", ID: 4}
+	after, _ := afterCursor.EncodeCursor()
+
+	mockRoleParam := entity.RoleParam{
+		Type: null.StringFrom(entity.RoleTypeAdmin),
+		PaginationParam: entity.PaginationParam{
+			SortBy:            []string{"-rank"},
+			Limit:             1,
+			IncludePagination: true,
+			UseCursor:         true,
+			After:             after,
+		},
+	}
+
+	mockInvalidSortParam := mockRoleParam
+	mockInvalidSortParam.SortBy = []string{"type"}
+
+	mockMismatchCursorParam := mockRoleParam
+	mockMismatchCursorParam.SortBy = []string{"rank"}
+
+	startCursor := entity.Cursor{SortBy: "-rank", Value: "40", ID: 5}
+	start, _ := startCursor.EncodeCursor()
+
+	mockPagination := entity.Pagination{
+		CurrentElements: 1,
+		TotalElements:   3,
+		SortBy:          []string{"-rank"},
+		CursorStart:     &start,
+		CursorEnd:       &start,
+		HasNextPage:     true,
+		HasPreviousPage: true,
+	}
+
+	// Test cases in here
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        []entity.Role
+		want1       *entity.Pagination
+		wantErr     bool
+	}{
+		{
+			name: "unsupported sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockInvalidSortParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.Role{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "cursor does not match sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockMismatchCursorParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.Role{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "error when query-ing",
+			args: args{
+				ctx:    context.Background(),
+				params: mockRoleParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WillReturnError(fmt.Errorf("failed to get list of role"))
+				return sqlServer, err
+			},
+			want:    []entity.Role{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "error when query-ing the previous page",
+			args: args{
+				ctx:    context.Background(),
+				params: mockRoleParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "rank", "created_at", "created_by", "updated_at", "updated_by"})
+				row.AddRow("5", "40", now, "test", now, "test")
+				sqlMock.ExpectQuery(query).WithArgs(entity.RoleTypeAdmin, 50, 50, 4).WillReturnRows(row)
+				sqlMock.ExpectQuery(queryPrevious).WillReturnError(fmt.Errorf("failed to count previous roles"))
+				return sqlServer, err
+			},
+			want: []entity.Role{
+				{
+					ID:        5,
+					Rank:      40,
+					CreatedAt: null.TimeFrom(now),
+					CreatedBy: null.StringFrom("test"),
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("test"),
+				},
+			},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "all good",
+			args: args{
+				ctx:    context.Background(),
+				params: mockRoleParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "rank", "created_at", "created_by", "updated_at", "updated_by"})
+				row.AddRow("5", "40", now, "test", now, "test")
+				row.AddRow("6", "30", now, "test", now, "test")
+				sqlMock.ExpectQuery(query).WithArgs(entity.RoleTypeAdmin, 50, 50, 4).WillReturnRows(row)
+
+				rowPrevious := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryPrevious).WithArgs(entity.RoleTypeAdmin, 50, 50, 4).WillReturnRows(rowPrevious)
+
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(3)
+				sqlMock.ExpectQuery(queryCount).WithArgs(entity.RoleTypeAdmin).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: []entity.Role{
+				{
+					ID:        5,
+					Rank:      40,
+					CreatedAt: null.TimeFrom(now),
+					CreatedBy: null.StringFrom("test"),
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("test"),
+				},
+			},
+			want1:   &mockPagination,
+			wantErr: false,
+		},
+	}
+
+	// Iterate the tests in here
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+
+			sqlClient := libsql.Init(libsql.Config{
+				Driver: "sqlmock",
+				Leader: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+				Follower: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+			}, logger, nil)
+
+			// Initialize the Domain
+			domain := Init(InitParam{
+				Log:  logger,
+				Db:   sqlClient,
+				Json: mockJsonParser,
+			})
+
+			got, pagination, err := domain.GetList(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Getlist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("domain.Getlist() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(pagination, tt.want1) {
+				t.Errorf("domain.Getlist() = %v, want1 %v", pagination, tt.want1)
+			}
+		})
+	}
+}
+
 func Test_role_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
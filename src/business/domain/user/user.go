@@ -69,6 +69,10 @@ func (u *user) Get(ctx context.Context, params entity.UserParam) (entity.User, e
 }
 
 func (u *user) GetList(ctx context.Context, params entity.UserParam) ([]entity.User, *entity.Pagination, error) {
+	if params.UseCursor {
+		return u.getSQLUserListByCursor(ctx, params)
+	}
+
 	return u.getSQLUserList(ctx, params)
 }
 
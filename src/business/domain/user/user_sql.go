@@ -62,6 +62,10 @@ func (u *user) getSQLUser(ctx context.Context, params entity.UserParam) (entity.
 func (u *user) getSQLUserList(ctx context.Context, params entity.UserParam) ([]entity.User, *entity.Pagination, error) {
 	users := []entity.User{}
 
+	if err := entity.ValidateSortBy(params.SortBy, entity.UserSortColumns); err != nil {
+		return users, nil, err
+	}
+
 	qb := query.NewSQLQueryBuilder(u.db, "param", "db", &params.QueryOption)
 	queryExt, queryArgs, countExt, countArgs, err := qb.Build(&params)
 	if err != nil {
@@ -100,6 +104,99 @@ func (u *user) getSQLUserList(ctx context.Context, params entity.UserParam) ([]e
 	return users, &pg, nil
 }
 
+func (u *user) getSQLUserListByCursor(ctx context.Context, params entity.UserParam) ([]entity.User, *entity.Pagination, error) {
+	users := []entity.User{}
+
+	sortBy, desc, column, err := entity.ParseCursorSortBy(params.SortBy, entity.UserSortColumns)
+	if err != nil {
+		return users, nil, err
+	}
+
+	limit := entity.CursorLimit(params.Limit)
+
+	// the builder only renders the filters, ordering and limit are driven by the cursor
+	params.SortBy = nil
+	params.Page = 0
+	params.Limit = 0
+	params.QueryOption.DisableLimit = true
+
+	qb := query.NewSQLQueryBuilder(u.db, "param", "db", &params.QueryOption)
+	queryExt, queryArgs, countExt, countArgs, err := qb.Build(&params)
+	if err != nil {
+		return users, nil, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	filterExt, filterArgs := queryExt, queryArgs
+	queryExt, queryArgs, err = entity.BuildKeysetQuery(queryExt, queryArgs, params.After, sortBy, desc, column, limit)
+	if err != nil {
+		return users, nil, err
+	}
+
+	rows, err := u.db.Follower().Query(ctx, "rListUserByCursor", readUser+queryExt, queryArgs...)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return users, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		temp := entity.User{}
+		if err := rows.StructScan(&temp); err != nil {
+			u.log.Error(ctx, errors.NewWithCode(codes.CodeSQLRowScan, err.Error()))
+			return users, nil, err
+		}
+		users = append(users, temp)
+	}
+
+	pg := entity.Pagination{
+		SortBy: []string{sortBy},
+	}
+
+	// rows may have been deleted since the cursor was handed out, only a row up to the cursor means a previous page
+	if params.After != "" {
+		previousExt, previousArgs, err := entity.BuildKeysetPreviousQuery(filterExt, filterArgs, params.After, sortBy, desc, column)
+		if err != nil {
+			return users, nil, err
+		}
+
+		var previous int64
+		if err := u.db.Follower().Get(ctx, "cUserPreviousByCursor", readUserCount+previousExt, &previous, previousArgs...); err != nil {
+			return users, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+		}
+		pg.HasPreviousPage = previous > 0
+	}
+
+	if int64(len(users)) > limit {
+		users = users[:limit]
+		pg.HasNextPage = true
+	}
+
+	pg.CurrentElements = int64(len(users))
+
+	if len(users) > 0 {
+		start, err := users[0].EncodeCursor(sortBy)
+		if err != nil {
+			return users, nil, err
+		}
+
+		end, err := users[len(users)-1].EncodeCursor(sortBy)
+		if err != nil {
+			return users, nil, err
+		}
+
+		pg.CursorStart = &start
+		pg.CursorEnd = &end
+	}
+
+	if params.IncludePagination {
+		if err := u.db.Follower().Get(ctx, "cUserByCursor", readUserCount+countExt, &pg.TotalElements, countArgs...); err != nil {
+			return users, nil, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+		}
+	}
+
+	return users, &pg, nil
+}
+
 func (u *user) updateSQLUser(ctx context.Context, updateParam entity.UpdateUserParam, selectParam entity.UserParam) error {
 	u.log.Debug(ctx, fmt.Sprintf("update user profile by: %v", selectParam))
 
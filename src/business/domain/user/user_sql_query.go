@@ -2,8 +2,8 @@ package user
 
 const (
 	createUser = `
-	INSERT INTO user (fk_role_id, email, username, password, display_name, created_by)
-	    VALUES (:fk_role_id, :email, :username, :password, :display_name, :created_by)`
+	INSERT INTO user (fk_role_id, email, username, password, display_name, status, created_by)
+	    VALUES (:fk_role_id, :email, :username, :password, :display_name, :status, :created_by)`
 
 	readUser = `
 	SELECT
@@ -13,6 +13,7 @@ const (
 	    username,
 	    password,
 	    display_name,
+	    avatar,
 	    status,
 	    created_at,
 	    created_by,
@@ -35,7 +35,7 @@ func Test_user_Create(t *testing.T) {
 
 	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
 
-	query := regexp.QuoteMeta(`INSERT INTO user (fk_role_id, email, username, password, display_name, created_by) VALUES (?, ?, ?, ?, ?, ?)`)
+	query := regexp.QuoteMeta(`INSERT INTO user (fk_role_id, email, username, password, display_name, status, created_by) VALUES (?, ?, ?, ?, ?, ?, ?)`)
 	queryGet := regexp.QuoteMeta(readUser)
 
 	// Type in here
@@ -52,6 +52,7 @@ func Test_user_Create(t *testing.T) {
 		Password:        "strongPassword",
 		ConfirmPassword: "strongPassword",
 		DisplayName:     "Display Name",
+		Status:          entity.UserStatusActive,
 	}
 
 	// Test cases in here
@@ -361,6 +362,9 @@ func Test_user_GetList(t *testing.T) {
 		},
 	}
 
+	mockInvalidSortParam := mockUserParam
+	mockInvalidSortParam.SortBy = []string{"-password"}
+
 	mockPagination := entity.Pagination{
 		CurrentPage:     1,
 		CurrentElements: 1,
@@ -378,6 +382,20 @@ func Test_user_GetList(t *testing.T) {
 		want1       *entity.Pagination
 		wantErr     bool
 	}{
+		{
+			name: "unsupported sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockInvalidSortParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.User{},
+			want1:   nil,
+			wantErr: true,
+		},
 		{
 			name: "error when query-ing",
 			args: args{
@@ -487,6 +505,228 @@ func Test_user_GetList(t *testing.T) {
 	}
 }
 
+func Test_user_GetListByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockJsonParser := mock_json.NewMockJSONInterface(ctrl)
+
+	// Type in here
+	type args struct {
+		ctx    context.Context
+		params entity.UserParam
+	}
+
+	// Mock in here
+	now := time.Now()
+	queryExt := " WHERE 1=1 AND fk_role_id=? AND id > ? ORDER BY id ASC LIMIT 2;"
+	query := regexp.QuoteMeta(readUser + queryExt)
+	queryCountExt := " WHERE 1=1 AND fk_role_id=?;"
+	queryCount := regexp.QuoteMeta(readUserCount + queryCountExt)
+	queryPreviousExt := " WHERE 1=1 AND fk_role_id=? AND id <= ?;"
+	queryPrevious := regexp.QuoteMeta(readUserCount + queryPreviousExt)
+
+	afterCursor := entity.Cursor{SortBy: "id", Value: "1", ID: 1}
+	after, _ := afterCursor.EncodeCursor()
+
+	mockUserParam := entity.UserParam{
+		RoleId: null.Int64From(2),
+		PaginationParam: entity.PaginationParam{
+			Limit:             1,
+			IncludePagination: true,
+			UseCursor:         true,
+			After:             after,
+		},
+	}
+
+	mockInvalidSortParam := mockUserParam
+	mockInvalidSortParam.SortBy = []string{"password"}
+
+	mockMismatchCursorParam := mockUserParam
+	mockMismatchCursorParam.SortBy = []string{"-created_at"}
+
+	startCursor := entity.Cursor{SortBy: "id", Value: "2", ID: 2}
+	start, _ := startCursor.EncodeCursor()
+
+	mockPagination := entity.Pagination{
+		CurrentElements: 1,
+		TotalElements:   3,
+		SortBy:          []string{"id"},
+		CursorStart:     &start,
+		CursorEnd:       &start,
+		HasNextPage:     true,
+		HasPreviousPage: true,
+	}
+
+	// every row up to the cursor was deleted since it was handed out
+	mockFirstPagePagination := mockPagination
+	mockFirstPagePagination.HasPreviousPage = false
+
+	// Test cases in here
+	tests := []struct {
+		name        string
+		args        args
+		prepSqlMock func() (*sql.DB, error)
+		want        []entity.User
+		want1       *entity.Pagination
+		wantErr     bool
+	}{
+		{
+			name: "unsupported sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockInvalidSortParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.User{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "cursor does not match sort by",
+			args: args{
+				ctx:    context.Background(),
+				params: mockMismatchCursorParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, _, err := sqlmock.New()
+				return sqlServer, err
+			},
+			want:    []entity.User{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "error when query-ing",
+			args: args{
+				ctx:    context.Background(),
+				params: mockUserParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				sqlMock.ExpectQuery(query).WillReturnError(fmt.Errorf("failed to get list of user"))
+				return sqlServer, err
+			},
+			want:    []entity.User{},
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "all good",
+			args: args{
+				ctx:    context.Background(),
+				params: mockUserParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "created_at", "created_by", "updated_at", "updated_by"})
+				row.AddRow("2", now, "test", now, "test")
+				row.AddRow("3", now, "test", now, "test")
+				sqlMock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(row)
+
+				rowPrevious := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(1)
+				sqlMock.ExpectQuery(queryPrevious).WithArgs(2, 1).WillReturnRows(rowPrevious)
+
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(3)
+				sqlMock.ExpectQuery(queryCount).WithArgs(2).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: []entity.User{
+				{
+					ID:        2,
+					CreatedAt: null.TimeFrom(now),
+					CreatedBy: null.StringFrom("test"),
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("test"),
+				},
+			},
+			want1:   &mockPagination,
+			wantErr: false,
+		},
+		{
+			name: "no previous page when no row is left before the cursor",
+			args: args{
+				ctx:    context.Background(),
+				params: mockUserParam,
+			},
+			prepSqlMock: func() (*sql.DB, error) {
+				sqlServer, sqlMock, err := sqlmock.New()
+				row := sqlmock.NewRows([]string{"id", "created_at", "created_by", "updated_at", "updated_by"})
+				row.AddRow("2", now, "test", now, "test")
+				row.AddRow("3", now, "test", now, "test")
+				sqlMock.ExpectQuery(query).WithArgs(2, 1).WillReturnRows(row)
+
+				rowPrevious := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(0)
+				sqlMock.ExpectQuery(queryPrevious).WithArgs(2, 1).WillReturnRows(rowPrevious)
+
+				rowCount := sqlMock.NewRows([]string{"COUNT(*)"}).AddRow(3)
+				sqlMock.ExpectQuery(queryCount).WithArgs(2).WillReturnRows(rowCount)
+				return sqlServer, err
+			},
+			want: []entity.User{
+				{
+					ID:        2,
+					CreatedAt: null.TimeFrom(now),
+					CreatedBy: null.StringFrom("test"),
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("test"),
+				},
+			},
+			want1:   &mockFirstPagePagination,
+			wantErr: false,
+		},
+	}
+
+	// Iterate the tests in here
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, err := tt.prepSqlMock()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+
+			sqlClient := libsql.Init(libsql.Config{
+				Driver: "sqlmock",
+				Leader: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+				Follower: libsql.ConnConfig{
+					MockDB: sqlServer,
+				},
+			}, logger, nil)
+
+			// Initialize the Domain
+			domain := Init(InitParam{
+				Log:  logger,
+				Db:   sqlClient,
+				Json: mockJsonParser,
+			})
+
+			got, pagination, err := domain.GetList(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Getlist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("domain.Getlist() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(pagination, tt.want1) {
+				t.Errorf("domain.Getlist() = %v, want1 %v", pagination, tt.want1)
+			}
+		})
+	}
+}
+
 func Test_user_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,102 @@
+package verificationtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/parser"
+	"github.com/adiatma85/own-go-sdk/query"
+	"github.com/adiatma85/own-go-sdk/sql"
+)
+
+type Interface interface {
+	Create(ctx context.Context, insertParam entity.CreateVerificationTokenParam) (entity.VerificationToken, error)
+	Get(ctx context.Context, params entity.VerificationTokenParam) (entity.VerificationToken, error)
+	GetActive(ctx context.Context, tokenHash string, now time.Time) (entity.VerificationToken, error)
+	MarkUsed(ctx context.Context, id int64, usedBy string, now time.Time) error
+	Update(ctx context.Context, updateParam entity.UpdateVerificationTokenParam, selectParam entity.VerificationTokenParam) error
+}
+
+type InitParam struct {
+	Log  log.Interface
+	Db   sql.Interface
+	Json parser.JSONInterface
+}
+
+type verificationToken struct {
+	log  log.Interface
+	db   sql.Interface
+	json parser.JSONInterface
+}
+
+func Init(param InitParam) Interface {
+	r := &verificationToken{
+		log:  param.Log,
+		db:   param.Db,
+		json: param.Json,
+	}
+
+	return r
+}
+
+func (r *verificationToken) Create(ctx context.Context, insertParam entity.CreateVerificationTokenParam) (entity.VerificationToken, error) {
+	result := entity.VerificationToken{}
+
+	tx, err := r.db.Leader().BeginTx(ctx, "txcVerificationToken", sql.TxOptions{})
+	if err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLTxBegin, err.Error())
+	}
+	defer tx.Rollback()
+
+	tx, result, err = r.createSQLVerificationToken(tx, insertParam)
+	if err != nil {
+		return result, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLTxCommit, err.Error())
+	}
+
+	return r.Get(ctx, entity.VerificationTokenParam{
+		ID: null.Int64From(result.ID),
+	})
+}
+
+func (r *verificationToken) Get(ctx context.Context, params entity.VerificationTokenParam) (entity.VerificationToken, error) {
+	return r.getSQLVerificationToken(ctx, params)
+}
+
+// GetActive returns the token of the hash unless it was used, revoked or expired at now
+func (r *verificationToken) GetActive(ctx context.Context, tokenHash string, now time.Time) (entity.VerificationToken, error) {
+	return r.getSQLVerificationToken(ctx, entity.VerificationTokenParam{
+		TokenHash:   null.StringFrom(tokenHash),
+		ExpiresAtGt: null.TimeFrom(now),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+}
+
+// MarkUsed uses an active token, only one of concurrent callers affects a row
+func (r *verificationToken) MarkUsed(ctx context.Context, id int64, usedBy string, now time.Time) error {
+	return r.updateSQLVerificationToken(ctx, entity.UpdateVerificationTokenParam{
+		Status:    null.Int64From(entity.VerificationTokenStatusUsed),
+		UsedAt:    null.TimeFrom(now),
+		UpdatedAt: null.TimeFrom(now),
+		UpdatedBy: null.StringFrom(usedBy),
+	}, entity.VerificationTokenParam{
+		ID: null.Int64From(id),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+}
+
+func (r *verificationToken) Update(ctx context.Context, updateParam entity.UpdateVerificationTokenParam, selectParam entity.VerificationTokenParam) error {
+	return r.updateSQLVerificationToken(ctx, updateParam, selectParam)
+}
@@ -0,0 +1,86 @@
+package verificationtoken
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/query"
+	"github.com/adiatma85/own-go-sdk/sql"
+)
+
+func (r *verificationToken) createSQLVerificationToken(tx sql.CommandTx, v entity.CreateVerificationTokenParam) (sql.CommandTx, entity.VerificationToken, error) {
+	verificationToken := entity.VerificationToken{}
+
+	res, err := tx.NamedExec("iCreateVerificationToken", createVerificationToken, v)
+	if err != nil {
+		return tx, verificationToken, errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil || rowCount < 1 {
+		return tx, verificationToken, errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no rows affected")
+	}
+
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		return tx, verificationToken, errors.NewWithCode(codes.CodeSQLNoRowsAffected, err.Error())
+	}
+
+	verificationToken.ID = lastID
+
+	return tx, verificationToken, nil
+}
+
+func (r *verificationToken) getSQLVerificationToken(ctx context.Context, params entity.VerificationTokenParam) (entity.VerificationToken, error) {
+	result := entity.VerificationToken{}
+
+	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &params.QueryOption)
+	queryExt, queryArgs, _, _, err := qb.Build(&params)
+	if err != nil {
+		return result, errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	row, err := r.db.Follower().QueryRow(ctx, "rVerificationToken", readVerificationToken+queryExt, queryArgs...)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRead, err.Error())
+	} else if errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, err.Error())
+	}
+
+	if err := row.StructScan(&result); err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRowScan, err.Error())
+	} else if errors.Is(err, sql.ErrNotFound) {
+		return result, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, err.Error())
+	}
+
+	return result, nil
+}
+
+func (r *verificationToken) updateSQLVerificationToken(ctx context.Context, updateParam entity.UpdateVerificationTokenParam, selectParam entity.VerificationTokenParam) error {
+	r.log.Debug(ctx, fmt.Sprintf("update verification token by: %v", selectParam))
+
+	qb := query.NewSQLQueryBuilder(r.db, "param", "db", &selectParam.QueryOption)
+
+	var err error
+	queryUpdate, args, err := qb.BuildUpdate(&updateParam, &selectParam)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLBuilder, err.Error())
+	}
+
+	res, err := r.db.Leader().Exec(ctx, "uVerificationToken", updateVerificationToken+queryUpdate, args...)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeSQLTxExec, err.Error())
+	}
+
+	rowCount, err := res.RowsAffected()
+	if err != nil || rowCount < 1 {
+		return errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no rows affected")
+	}
+
+	r.log.Debug(ctx, fmt.Sprintf("successfully updated verification token: %v", updateParam))
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package verificationtoken
+
+const (
+	createVerificationToken = `
+	INSERT INTO verification_token (fk_user_id, token_hash, expires_at, created_by)
+	    VALUES (:fk_user_id, :token_hash, :expires_at, :created_by)`
+
+	readVerificationToken = `
+	SELECT
+	    id,
+	    fk_user_id,
+	    token_hash,
+	    expires_at,
+	    used_at,
+	    revoked_at,
+	    status,
+	    created_at,
+	    created_by,
+	    updated_at,
+	    updated_by
+	FROM
+	    verification_token`
+
+	updateVerificationToken = `
+	UPDATE
+	    verification_token`
+)
@@ -0,0 +1,258 @@
+package verificationtoken
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+	libsql "github.com/adiatma85/own-go-sdk/sql"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	mock_json "github.com/adiatma85/own-go-sdk/tests/mock/parser"
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDomain(t *testing.T, sqlServer *sql.DB) Interface {
+	ctrl := gomock.NewController(t)
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+
+	sqlClient := libsql.Init(libsql.Config{
+		Driver: "sqlmock",
+		Leader: libsql.ConnConfig{
+			MockDB: sqlServer,
+		},
+		Follower: libsql.ConnConfig{
+			MockDB: sqlServer,
+		},
+	}, logger, nil)
+
+	return Init(InitParam{
+		Log:  logger,
+		Db:   sqlClient,
+		Json: mock_json.NewMockJSONInterface(ctrl),
+	})
+}
+
+func Test_verificationToken_Create(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	createParam := entity.CreateVerificationTokenParam{
+		UserID:    42,
+		TokenHash: entity.HashToken("token"),
+		ExpiresAt: null.TimeFrom(expiresAt),
+		CreatedBy: null.StringFrom("42"),
+	}
+
+	query := regexp.QuoteMeta(`INSERT INTO verification_token (fk_user_id, token_hash, expires_at, created_by) VALUES (?, ?, ?, ?)`)
+	queryGet := regexp.QuoteMeta(readVerificationToken + " WHERE 1=1 AND id=?")
+
+	tests := []struct {
+		name        string
+		prepSqlMock func(sqlMock sqlmock.Sqlmock)
+		want        entity.VerificationToken
+		wantErr     bool
+	}{
+		{
+			name: "cannot exec verification token",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnError(errors.NewWithCode(codes.CodeSQL, "cannot create verification token"))
+				sqlMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "verification token no new row",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "all good",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(query).
+					WithArgs(createParam.UserID, createParam.TokenHash, createParam.ExpiresAt, createParam.CreatedBy).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				sqlMock.ExpectCommit()
+
+				row := sqlMock.NewRows([]string{"id", "fk_user_id", "token_hash", "status"})
+				row.AddRow("7", "42", createParam.TokenHash, entity.VerificationTokenStatusActive)
+				sqlMock.ExpectQuery(queryGet).WithArgs(7).WillReturnRows(row)
+			},
+			want: entity.VerificationToken{
+				ID:        7,
+				UserID:    42,
+				TokenHash: createParam.TokenHash,
+				Status:    entity.VerificationTokenStatusActive,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			tt.prepSqlMock(sqlMock)
+
+			got, err := newTestDomain(t, sqlServer).Create(context.Background(), createParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("domain.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_verificationToken_GetActive(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	tokenHash := entity.HashToken("token")
+
+	// used and revoked tokens are not active, expired ones are left out by their expiry
+	query := regexp.QuoteMeta(readVerificationToken + " WHERE 1=1 AND status=1 AND token_hash=? AND expires_at>?")
+
+	tests := []struct {
+		name        string
+		prepSqlMock func(sqlMock sqlmock.Sqlmock)
+		want        entity.VerificationToken
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name: "used, revoked or expired token",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(query).WithArgs(tokenHash, now).WillReturnError(libsql.ErrNotFound)
+			},
+			wantCode: codes.CodeSQLRecordDoesNotExist,
+			wantErr:  true,
+		},
+		{
+			name: "failed to read",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(query).WithArgs(tokenHash, now).WillReturnError(assert.AnError)
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+		{
+			name: "active token",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				row := sqlMock.NewRows([]string{"id", "fk_user_id", "token_hash", "status"})
+				row.AddRow("7", "42", tokenHash, entity.VerificationTokenStatusActive)
+				sqlMock.ExpectQuery(query).WithArgs(tokenHash, now).WillReturnRows(row)
+			},
+			want: entity.VerificationToken{
+				ID:        7,
+				UserID:    42,
+				TokenHash: tokenHash,
+				Status:    entity.VerificationTokenStatusActive,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			tt.prepSqlMock(sqlMock)
+
+			got, err := newTestDomain(t, sqlServer).GetActive(context.Background(), tokenHash, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.GetActive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_verificationToken_MarkUsed(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	// only an active token is used, a concurrent caller affects no row
+	query := regexp.QuoteMeta("UPDATE verification_token SET status=?, used_at=?, updated_at=?, updated_by=? WHERE 1=1 AND status=1 AND id=?")
+
+	tests := []struct {
+		name        string
+		prepSqlMock func(sqlMock sqlmock.Sqlmock)
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name: "failed to exec update",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec(query).WillReturnError(assert.AnError)
+			},
+			wantCode: codes.CodeSQLTxExec,
+			wantErr:  true,
+		},
+		{
+			name: "token is no longer active",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec(query).WillReturnResult(driver.RowsAffected(0))
+			},
+			wantCode: codes.CodeSQLNoRowsAffected,
+			wantErr:  true,
+		},
+		{
+			name: "token is used",
+			prepSqlMock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec(query).
+					WithArgs(entity.VerificationTokenStatusUsed, now, now, "42", 7).
+					WillReturnResult(driver.RowsAffected(1))
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlServer, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Error(err)
+			}
+			defer sqlServer.Close()
+			tt.prepSqlMock(sqlMock)
+
+			err = newTestDomain(t, sqlServer).MarkUsed(context.Background(), 7, "42", now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("domain.MarkUsed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				return
+			}
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
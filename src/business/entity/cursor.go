@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+const (
+	// Sort Kind Enum, decides how a cursor value is bound to the query
+	SortKindInt    = "int"
+	SortKindString = "string"
+	SortKindTime   = "time"
+
+	DefaultCursorSortBy = "id"
+	DefaultCursorLimit  = 10
+	MaxCursorLimit      = 100
+)
+
+type SortColumn struct {
+	Column string
+	Kind   string
+}
+
+// Cursor is the opaque position of a row for keyset pagination, it keeps the sort key,
+// the sort value of the row and the row id as the tie breaker
+type Cursor struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v"`
+	ID     int64  `json:"i"`
+}
+
+func (c *Cursor) EncodeCursor() (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.NewWithCode(codes.CodeMarshal, err.Error())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func (c *Cursor) DecodeCursor(v string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+	}
+
+	if err := json.Unmarshal(raw, c); err != nil {
+		return errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+	}
+
+	return nil
+}
+
+// SortArg converts the cursor value back into a query argument of the sort column kind
+func (c *Cursor) SortArg(kind string) (interface{}, error) {
+	switch kind {
+	case SortKindInt:
+		v, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+		}
+		return v, nil
+	case SortKindTime:
+		v, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeBadRequest, "invalid cursor")
+		}
+		return v, nil
+	default:
+		return c.Value, nil
+	}
+}
+
+// ParseCursorSortBy returns the sort key, whether it is descending and its column from the allowed columns
+func ParseCursorSortBy(sortBy []string, columns map[string]SortColumn) (string, bool, SortColumn, error) {
+	key := DefaultCursorSortBy
+	if len(sortBy) > 0 && sortBy[0] != "" {
+		key = strings.TrimSpace(sortBy[0])
+	}
+
+	desc := strings.HasPrefix(key, "-")
+	column, ok := columns[strings.TrimPrefix(key, "-")]
+	if !ok {
+		return key, desc, column, errors.NewWithCode(codes.CodeBadRequest, "sort by %s is not supported", key)
+	}
+
+	return key, desc, column, nil
+}
+
+// CursorLimit returns the page size of a keyset page, defaulting and capping the requested limit
+func CursorLimit(limit int64) int64 {
+	if limit < 1 {
+		return DefaultCursorLimit
+	}
+
+	if limit > MaxCursorLimit {
+		return MaxCursorLimit
+	}
+
+	return limit
+}
+
+// BuildKeysetQuery appends the keyset condition, order and limit onto a query built by the sdk query builder.
+// One extra row is fetched so the caller can tell whether there is a next page.
+func BuildKeysetQuery(queryExt string, queryArgs []interface{}, after string, sortBy string, desc bool, column SortColumn, limit int64) (string, []interface{}, error) {
+	queryExt = strings.TrimSuffix(strings.TrimSpace(queryExt), ";")
+	args := append([]interface{}{}, queryArgs...)
+
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+
+	if after != "" {
+		condition, conditionArgs, err := keysetCondition(after, sortBy, column, op, op)
+		if err != nil {
+			return "", nil, err
+		}
+
+		queryExt += condition
+		args = append(args, conditionArgs...)
+	}
+
+	if column.Column == "id" {
+		queryExt += fmt.Sprintf(" ORDER BY id %s", dir)
+	} else {
+		queryExt += fmt.Sprintf(" ORDER BY %s %s, id %s", column.Column, dir, dir)
+	}
+
+	queryExt += fmt.Sprintf(" LIMIT %d;", limit+1)
+
+	return " " + queryExt, args, nil
+}
+
+// BuildKeysetPreviousQuery appends the condition matching every row up to and including the cursor row
+// onto a query built by the sdk query builder. Counting them tells whether there is a previous page.
+func BuildKeysetPreviousQuery(queryExt string, queryArgs []interface{}, after string, sortBy string, desc bool, column SortColumn) (string, []interface{}, error) {
+	queryExt = strings.TrimSuffix(strings.TrimSpace(queryExt), ";")
+	args := append([]interface{}{}, queryArgs...)
+
+	op, idOp := "<", "<="
+	if desc {
+		op, idOp = ">", ">="
+	}
+
+	condition, conditionArgs, err := keysetCondition(after, sortBy, column, op, idOp)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return " " + queryExt + condition + ";", append(args, conditionArgs...), nil
+}
+
+// keysetCondition compares the rows with the cursor row, op applies to the sort column and idOp to the tie breaker
+func keysetCondition(after string, sortBy string, column SortColumn, op string, idOp string) (string, []interface{}, error) {
+	cursor := Cursor{}
+	if err := cursor.DecodeCursor(after); err != nil {
+		return "", nil, err
+	}
+
+	if cursor.SortBy != sortBy {
+		return "", nil, errors.NewWithCode(codes.CodeBadRequest, "cursor does not match sort by %s", sortBy)
+	}
+
+	if column.Column == "id" {
+		return fmt.Sprintf(" AND id %s ?", idOp), []interface{}{cursor.ID}, nil
+	}
+
+	value, err := cursor.SortArg(column.Kind)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[3]s ?))", column.Column, op, idOp), []interface{}{value, value, cursor.ID}, nil
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ValidateSortBy rejects any sort key that is not one of the allowed columns,
+// the query builder would otherwise drop or pass them through silently
+func ValidateSortBy(sortBy []string, columns map[string]SortColumn) error {
+	for _, param := range sortBy {
+		for _, key := range strings.Split(param, ",") {
+			key = strings.TrimPrefix(strings.TrimSpace(key), "-")
+			if key == "" {
+				continue
+			}
+
+			if _, ok := columns[key]; !ok {
+				return errors.NewWithCode(codes.CodeBadRequest, "sort by %s is not supported", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// apply sets the equality, in and like params of a string column.
+// The query builder turns any value containing % into a LIKE, so user input is escaped first.
+func (f *QlStringFilter) apply(eq *null.String, in *[]string, like *null.String) error {
+	if f == nil {
+		return nil
+	}
+
+	if f.Prefix != nil && f.Contains != nil {
+		return errors.NewWithCode(codes.CodeBadRequest, "only one of prefix or contains can be used")
+	}
+
+	if f.Eq != nil {
+		value := *f.Eq
+		if strings.ContainsRune(value, '%') {
+			value = likeEscaper.Replace(value)
+		}
+		*eq = null.StringFrom(value)
+	}
+
+	if len(f.In) > 0 {
+		*in = f.In
+	}
+
+	if f.Prefix != nil && *f.Prefix != "" {
+		*like = null.StringFrom(likeEscaper.Replace(*f.Prefix) + "%")
+	}
+
+	if f.Contains != nil && *f.Contains != "" {
+		*like = null.StringFrom("%" + likeEscaper.Replace(*f.Contains) + "%")
+	}
+
+	return nil
+}
+
+func (f *QlIntFilter) apply(eq *null.Int64, in *[]int64) {
+	if f == nil {
+		return
+	}
+
+	if f.Eq != nil {
+		*eq = null.Int64From(int64(*f.Eq))
+	}
+
+	for _, v := range f.In {
+		*in = append(*in, int64(v))
+	}
+}
+
+func (f *QlIDFilter) apply(eq *null.Int64, in *[]int64) {
+	if f == nil {
+		return
+	}
+
+	if f.Eq != nil {
+		*eq = null.Int64From(*f.Eq)
+	}
+
+	*in = append(*in, f.In...)
+}
+
+// apply leaves the bounds unset when they are missing, the Time scalar already rejected invalid ones
+func (f *QlTimeRangeFilter) apply(from *null.Time, to *null.Time) {
+	if f == nil {
+		return
+	}
+
+	if f.From != nil {
+		*from = *f.From
+	}
+
+	if f.To != nil {
+		*to = *f.To
+	}
+}
+
+func convertSortDirection(key string, direction *QlSortDirection) string {
+	if direction != nil && *direction == QlSortDirectionDesc {
+		return "-" + key
+	}
+
+	return key
+}
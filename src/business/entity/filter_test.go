@@ -0,0 +1,237 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_QlStringFilter_apply(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	type result struct {
+		eq   null.String
+		in   []string
+		like null.String
+	}
+
+	tests := []struct {
+		name    string
+		filter  *QlStringFilter
+		want    result
+		wantErr bool
+	}{
+		{
+			name:   "nil filter sets nothing",
+			filter: nil,
+			want:   result{},
+		},
+		{
+			name:   "eq without wildcards stays an equality",
+			filter: &QlStringFilter{Eq: str(`a_b\c`)},
+			want:   result{eq: null.StringFrom(`a_b\c`)},
+		},
+		{
+			name:   "eq with a percent is escaped so it matches literally",
+			filter: &QlStringFilter{Eq: str(`50%_off\`)},
+			want:   result{eq: null.StringFrom(`50\%\_off\\`)},
+		},
+		{
+			name:   "in",
+			filter: &QlStringFilter{In: []string{"a", "b"}},
+			want:   result{in: []string{"a", "b"}},
+		},
+		{
+			name:   "prefix",
+			filter: &QlStringFilter{Prefix: str("jane")},
+			want:   result{like: null.StringFrom("jane%")},
+		},
+		{
+			name:   "prefix escapes percent, underscore and backslash",
+			filter: &QlStringFilter{Prefix: str(`100%_a\b`)},
+			want:   result{like: null.StringFrom(`100\%\_a\\b%`)},
+		},
+		{
+			name:   "contains",
+			filter: &QlStringFilter{Contains: str("doe")},
+			want:   result{like: null.StringFrom("%doe%")},
+		},
+		{
+			name:   "contains escapes percent, underscore and backslash",
+			filter: &QlStringFilter{Contains: str(`%_\`)},
+			want:   result{like: null.StringFrom(`%\%\_\\%`)},
+		},
+		{
+			name:   "empty prefix and contains match everything, no like is set",
+			filter: &QlStringFilter{Prefix: str("")},
+			want:   result{},
+		},
+		{
+			name:    "prefix and contains together are rejected",
+			filter:  &QlStringFilter{Prefix: str("a"), Contains: str("b")},
+			wantErr: true,
+		},
+		{
+			name:    "prefix and contains together are rejected even when empty",
+			filter:  &QlStringFilter{Prefix: str(""), Contains: str("")},
+			wantErr: true,
+		},
+		{
+			name:   "every operator at once",
+			filter: &QlStringFilter{Eq: str("a"), In: []string{"b"}, Contains: str("c")},
+			want:   result{eq: null.StringFrom("a"), in: []string{"b"}, like: null.StringFrom("%c%")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := result{}
+			err := tt.filter.apply(&got.eq, &got.in, &got.like)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QlStringFilter.apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				assert.Equal(t, result{}, got, "a rejected filter sets nothing")
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_QlUserFilter_ApplyToUserParam(t *testing.T) {
+	str := func(s string) *string { return &s }
+	id := func(i int64) *int64 { return &i }
+	from := null.TimeFrom(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	to := null.TimeFrom(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		filter  *QlUserFilter
+		param   UserParam
+		want    UserParam
+		wantErr bool
+	}{
+		{
+			name:   "nil filter keeps the param",
+			filter: nil,
+			param:  UserParam{ID: null.Int64From(1)},
+			want:   UserParam{ID: null.Int64From(1)},
+		},
+		{
+			name: "every field",
+			filter: &QlUserFilter{
+				ID:          &QlIDFilter{Eq: id(1), In: []int64{2, 3}},
+				RoleID:      &QlIDFilter{In: []int64{4}},
+				Email:       &QlStringFilter{Eq: str("jane@example.com")},
+				Username:    &QlStringFilter{Prefix: str("ja_")},
+				DisplayName: &QlStringFilter{Contains: str("100%")},
+				CreatedAt:   &QlTimeRangeFilter{From: &from, To: &to},
+			},
+			want: UserParam{
+				ID:              null.Int64From(1),
+				IDs:             []int64{2, 3},
+				RoleIds:         []int64{4},
+				Email:           null.StringFrom("jane@example.com"),
+				UsernameLike:    null.StringFrom(`ja\_%`),
+				DisplayNameLike: null.StringFrom(`%100\%%`),
+				CreatedAtGte:    from,
+				CreatedAtLte:    to,
+			},
+		},
+		{
+			name:    "prefix and contains of the email",
+			filter:  &QlUserFilter{Email: &QlStringFilter{Prefix: str("a"), Contains: str("b")}},
+			wantErr: true,
+		},
+		{
+			name:    "prefix and contains of the display name",
+			filter:  &QlUserFilter{DisplayName: &QlStringFilter{Prefix: str("a"), Contains: str("b")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := tt.param
+			err := tt.filter.ApplyToUserParam(&param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QlUserFilter.ApplyToUserParam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, param)
+		})
+	}
+}
+
+func Test_QlRoleFilter_ApplyToRoleParam(t *testing.T) {
+	str := func(s string) *string { return &s }
+	rank := func(i int) *int { return &i }
+	from := null.TimeFrom(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		filter  *QlRoleFilter
+		param   RoleParam
+		want    RoleParam
+		wantErr bool
+	}{
+		{
+			name:   "nil filter keeps the param",
+			filter: nil,
+			param:  RoleParam{ID: null.Int64From(1)},
+			want:   RoleParam{ID: null.Int64From(1)},
+		},
+		{
+			name: "every field",
+			filter: &QlRoleFilter{
+				ID:        &QlIDFilter{In: []int64{2, 3}},
+				Name:      &QlStringFilter{Contains: str(`a\b`)},
+				Type:      &QlStringFilter{In: []string{RoleTypeAdmin}},
+				Rank:      &QlIntFilter{Eq: rank(10), In: []int{20, 30}},
+				CreatedAt: &QlTimeRangeFilter{From: &from},
+			},
+			want: RoleParam{
+				IDs:          []int64{2, 3},
+				NameLike:     null.StringFrom(`%a\\b%`),
+				Types:        []string{RoleTypeAdmin},
+				Rank:         null.Int64From(10),
+				Ranks:        []int64{20, 30},
+				CreatedAtGte: from,
+			},
+		},
+		{
+			name:    "prefix and contains of the name",
+			filter:  &QlRoleFilter{Name: &QlStringFilter{Prefix: str("a"), Contains: str("b")}},
+			wantErr: true,
+		},
+		{
+			name:    "prefix and contains of the type",
+			filter:  &QlRoleFilter{Type: &QlStringFilter{Prefix: str("a"), Contains: str("b")}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := tt.param
+			err := tt.filter.ApplyToRoleParam(&param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QlRoleFilter.ApplyToRoleParam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, param)
+		})
+	}
+}
@@ -2,9 +2,37 @@
 
 package entity
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/adiatma85/own-go-sdk/null"
+)
+
 type Mutation struct {
 }
 
+type QlChangePasswordParam struct {
+	OldPassword     string `json:"oldPassword"`
+	NewPassword     string `json:"newPassword"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
+
+type QlCreateRoleParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Rank int    `json:"rank"`
+}
+
+type QlCreateUserAdminParam struct {
+	RoleID      int64  `json:"roleId"`
+	Email       string `json:"email"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	DisplayName string `json:"displayName"`
+}
+
 type QlCreateUserParam struct {
 	Email           string `json:"email"`
 	Username        string `json:"username"`
@@ -13,17 +41,144 @@ type QlCreateUserParam struct {
 	DisplayName     string `json:"displayName"`
 }
 
+type QlIDFilter struct {
+	Eq *int64  `json:"eq,omitempty"`
+	In []int64 `json:"in,omitempty"`
+}
+
+type QlIntFilter struct {
+	Eq *int  `json:"eq,omitempty"`
+	In []int `json:"in,omitempty"`
+}
+
 type QlLogin struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
-type QlUser struct {
-	ID          int    `json:"id"`
-	Roleid      int    `json:"roleid"`
-	Email       string `json:"email"`
-	Username    string `json:"username"`
-	Displayname string `json:"displayname"`
+type QlPageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+	TotalCount      int     `json:"totalCount"`
+}
+
+type QlPagination struct {
+	CurrentPage     int `json:"currentPage"`
+	CurrentElements int `json:"currentElements"`
+	TotalPages      int `json:"totalPages"`
+	TotalElements   int `json:"totalElements"`
+}
+
+type QlRefreshTokenParam struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type QlRefreshTokenResponse struct {
+	AccessToken           string    `json:"accessToken"`
+	RefreshToken          string    `json:"refreshToken"`
+	RefreshTokenExpiresAt null.Time `json:"refreshTokenExpiresAt"`
+}
+
+type QlRole struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Rank   int    `json:"rank"`
+	Status int    `json:"status"`
+}
+
+type QlRoleConnection struct {
+	Edges    []QlRoleEdge `json:"edges"`
+	PageInfo *QlPageInfo  `json:"pageInfo"`
+}
+
+type QlRoleEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *QlRole `json:"node"`
+}
+
+type QlRoleFilter struct {
+	ID        *QlIDFilter        `json:"id,omitempty"`
+	Name      *QlStringFilter    `json:"name,omitempty"`
+	Type      *QlStringFilter    `json:"type,omitempty"`
+	Rank      *QlIntFilter       `json:"rank,omitempty"`
+	CreatedAt *QlTimeRangeFilter `json:"createdAt,omitempty"`
+}
+
+type QlRoleList struct {
+	Roles      []QlRole      `json:"roles"`
+	Pagination *QlPagination `json:"pagination"`
+}
+
+type QlRoleOrderBy struct {
+	Field     QlRoleSortField  `json:"field"`
+	Direction *QlSortDirection `json:"direction,omitempty"`
+}
+
+type QlRoleParam struct {
+	Name  *string `json:"name,omitempty"`
+	Type  *string `json:"type,omitempty"`
+	Page  *int    `json:"page,omitempty"`
+	Limit *int    `json:"limit,omitempty"`
+}
+
+type QlStringFilter struct {
+	Eq       *string  `json:"eq,omitempty"`
+	In       []string `json:"in,omitempty"`
+	Prefix   *string  `json:"prefix,omitempty"`
+	Contains *string  `json:"contains,omitempty"`
+}
+
+type QlTimeRangeFilter struct {
+	From *null.Time `json:"from,omitempty"`
+	To   *null.Time `json:"to,omitempty"`
+}
+
+type QlUpdateRoleParam struct {
+	Name *string `json:"name,omitempty"`
+	Type *string `json:"type,omitempty"`
+	Rank *int    `json:"rank,omitempty"`
+}
+
+type QlUpdateSelfParam struct {
+	Username    *string `json:"username,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+type QlUpdateUserParam struct {
+	RoleID      *int64  `json:"roleId,omitempty"`
+	Username    *string `json:"username,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+type QlUserByIDsInput struct {
+	ID int64 `json:"ID"`
+}
+
+type QlUserConnection struct {
+	Edges    []QlUserEdge `json:"edges"`
+	PageInfo *QlPageInfo  `json:"pageInfo"`
+}
+
+type QlUserEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *QlUser `json:"node"`
+}
+
+type QlUserFilter struct {
+	ID          *QlIDFilter        `json:"id,omitempty"`
+	RoleID      *QlIDFilter        `json:"roleId,omitempty"`
+	Email       *QlStringFilter    `json:"email,omitempty"`
+	Username    *QlStringFilter    `json:"username,omitempty"`
+	DisplayName *QlStringFilter    `json:"displayName,omitempty"`
+	CreatedAt   *QlTimeRangeFilter `json:"createdAt,omitempty"`
+}
+
+type QlUserList struct {
+	Users      []QlUser      `json:"users"`
+	Pagination *QlPagination `json:"pagination"`
 }
 
 type QlUserLoginResponse struct {
@@ -33,5 +188,241 @@ type QlUserLoginResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+type QlUserOrderBy struct {
+	Field     QlUserSortField  `json:"field"`
+	Direction *QlSortDirection `json:"direction,omitempty"`
+}
+
+type QlUserParam struct {
+	Email       *string `json:"email,omitempty"`
+	Username    *string `json:"username,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	RoleID      *int64  `json:"roleId,omitempty"`
+	Page        *int    `json:"page,omitempty"`
+	Limit       *int    `json:"limit,omitempty"`
+}
+
 type Query struct {
 }
+
+type Subscription struct {
+}
+
+type QlCacheControlScope string
+
+const (
+	QlCacheControlScopePublic  QlCacheControlScope = "PUBLIC"
+	QlCacheControlScopePrivate QlCacheControlScope = "PRIVATE"
+)
+
+var AllQlCacheControlScope = []QlCacheControlScope{
+	QlCacheControlScopePublic,
+	QlCacheControlScopePrivate,
+}
+
+func (e QlCacheControlScope) IsValid() bool {
+	switch e {
+	case QlCacheControlScopePublic, QlCacheControlScopePrivate:
+		return true
+	}
+	return false
+}
+
+func (e QlCacheControlScope) String() string {
+	return string(e)
+}
+
+func (e *QlCacheControlScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QlCacheControlScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QlCacheControlScope", str)
+	}
+	return nil
+}
+
+func (e QlCacheControlScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QlRoleSortField string
+
+const (
+	QlRoleSortFieldID        QlRoleSortField = "ID"
+	QlRoleSortFieldName      QlRoleSortField = "NAME"
+	QlRoleSortFieldRank      QlRoleSortField = "RANK"
+	QlRoleSortFieldCreatedAt QlRoleSortField = "CREATED_AT"
+	QlRoleSortFieldUpdatedAt QlRoleSortField = "UPDATED_AT"
+)
+
+var AllQlRoleSortField = []QlRoleSortField{
+	QlRoleSortFieldID,
+	QlRoleSortFieldName,
+	QlRoleSortFieldRank,
+	QlRoleSortFieldCreatedAt,
+	QlRoleSortFieldUpdatedAt,
+}
+
+func (e QlRoleSortField) IsValid() bool {
+	switch e {
+	case QlRoleSortFieldID, QlRoleSortFieldName, QlRoleSortFieldRank, QlRoleSortFieldCreatedAt, QlRoleSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e QlRoleSortField) String() string {
+	return string(e)
+}
+
+func (e *QlRoleSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QlRoleSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QlRoleSortField", str)
+	}
+	return nil
+}
+
+func (e QlRoleSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QlRoleType string
+
+const (
+	QlRoleTypeAdmin QlRoleType = "ADMIN"
+	QlRoleTypeUser  QlRoleType = "USER"
+)
+
+var AllQlRoleType = []QlRoleType{
+	QlRoleTypeAdmin,
+	QlRoleTypeUser,
+}
+
+func (e QlRoleType) IsValid() bool {
+	switch e {
+	case QlRoleTypeAdmin, QlRoleTypeUser:
+		return true
+	}
+	return false
+}
+
+func (e QlRoleType) String() string {
+	return string(e)
+}
+
+func (e *QlRoleType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QlRoleType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QlRoleType", str)
+	}
+	return nil
+}
+
+func (e QlRoleType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QlSortDirection string
+
+const (
+	QlSortDirectionAsc  QlSortDirection = "ASC"
+	QlSortDirectionDesc QlSortDirection = "DESC"
+)
+
+var AllQlSortDirection = []QlSortDirection{
+	QlSortDirectionAsc,
+	QlSortDirectionDesc,
+}
+
+func (e QlSortDirection) IsValid() bool {
+	switch e {
+	case QlSortDirectionAsc, QlSortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e QlSortDirection) String() string {
+	return string(e)
+}
+
+func (e *QlSortDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QlSortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QlSortDirection", str)
+	}
+	return nil
+}
+
+func (e QlSortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type QlUserSortField string
+
+const (
+	QlUserSortFieldID          QlUserSortField = "ID"
+	QlUserSortFieldEmail       QlUserSortField = "EMAIL"
+	QlUserSortFieldUsername    QlUserSortField = "USERNAME"
+	QlUserSortFieldDisplayName QlUserSortField = "DISPLAY_NAME"
+	QlUserSortFieldCreatedAt   QlUserSortField = "CREATED_AT"
+	QlUserSortFieldUpdatedAt   QlUserSortField = "UPDATED_AT"
+)
+
+var AllQlUserSortField = []QlUserSortField{
+	QlUserSortFieldID,
+	QlUserSortFieldEmail,
+	QlUserSortFieldUsername,
+	QlUserSortFieldDisplayName,
+	QlUserSortFieldCreatedAt,
+	QlUserSortFieldUpdatedAt,
+}
+
+func (e QlUserSortField) IsValid() bool {
+	switch e {
+	case QlUserSortFieldID, QlUserSortFieldEmail, QlUserSortFieldUsername, QlUserSortFieldDisplayName, QlUserSortFieldCreatedAt, QlUserSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e QlUserSortField) String() string {
+	return string(e)
+}
+
+func (e *QlUserSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QlUserSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid QlUserSortField", str)
+	}
+	return nil
+}
+
+func (e QlUserSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -42,6 +42,8 @@ type Pagination struct {
 	SortBy          []string `json:"sortBy"`
 	CursorStart     *string  `json:"cursorStart,omitempty"`
 	CursorEnd       *string  `json:"cursorEnd,omitempty"`
+	HasNextPage     bool     `json:"hasNextPage"`
+	HasPreviousPage bool     `json:"hasPreviousPage"`
 }
 
 func (p *Pagination) ProcessPagination(limit int64) {
@@ -68,12 +70,51 @@ func (p *Pagination) ProcessPagination(limit int64) {
 	}
 }
 
+func (p *Pagination) ConvertToQlPagination() *QlPagination {
+	if p == nil {
+		return &QlPagination{}
+	}
+
+	return &QlPagination{
+		CurrentPage:     int(p.CurrentPage),
+		CurrentElements: int(p.CurrentElements),
+		TotalPages:      int(p.TotalPages),
+		TotalElements:   int(p.TotalElements),
+	}
+}
+
+func (p *Pagination) ConvertToQlPageInfo() *QlPageInfo {
+	if p == nil {
+		return &QlPageInfo{}
+	}
+
+	return &QlPageInfo{
+		HasNextPage:     p.HasNextPage,
+		HasPreviousPage: p.HasPreviousPage,
+		StartCursor:     p.CursorStart,
+		EndCursor:       p.CursorEnd,
+		TotalCount:      int(p.TotalElements),
+	}
+}
+
+// CursorSortBy returns the sort key the page cursors were encoded with
+func (p *Pagination) CursorSortBy() string {
+	if p == nil || len(p.SortBy) == 0 {
+		return DefaultCursorSortBy
+	}
+
+	return p.SortBy[0]
+}
+
 type PaginationParam struct {
 	GroupBy           []string `param:"-" db:"-"`
 	SortBy            []string `param:"sort_by" db:"sort_by"`
 	Limit             int64    `form:"limit" param:"limit" db:"limit"`
 	Page              int64    `form:"page" param:"page" db:"page"`
 	IncludePagination bool
+	// Keyset pagination, After is the opaque cursor of the last seen row
+	UseCursor bool   `param:"-" db:"-"`
+	After     string `param:"-" db:"-"`
 }
 
 type Authorize struct {
@@ -1,6 +1,12 @@
 package entity
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
 	"github.com/adiatma85/own-go-sdk/null"
 	"github.com/adiatma85/own-go-sdk/query"
 )
@@ -13,6 +19,15 @@ const (
 	RoleTypeUser  = "user"
 )
 
+// RoleSortColumns are the sort keys allowed for role lists
+var RoleSortColumns = map[string]SortColumn{
+	"id":         {Column: "id", Kind: SortKindInt},
+	"name":       {Column: "name", Kind: SortKindString},
+	"rank":       {Column: "rank", Kind: SortKindInt},
+	"created_at": {Column: "created_at", Kind: SortKindTime},
+	"updated_at": {Column: "updated_at", Kind: SortKindTime},
+}
+
 type Role struct {
 	ID        int64       `db:"id" json:"id"`
 	Name      string      `db:"name" json:"name"`
@@ -30,9 +45,20 @@ type Role struct {
 type RoleParam struct {
 	ID     null.Int64  `param:"id" uri:"role_id" db:"id" form:"role_id"`
 	IDs    []int64     `param:"ids" uri:"role_ids" db:"id"`
-	Name   null.String `param:"name" uri:"role_name" db:"id" form:"role_name"`
-	Type   null.String `param:"type" uri:"role_type" db:"id" form:"role_type"`
+	Name   null.String `param:"name" uri:"role_name" db:"name" form:"role_name"`
+	Type   null.String `param:"type" uri:"role_type" db:"type" form:"role_type"`
 	Status null.Int64  `param:"status" db:"status" swaggertype:"string"`
+	// Filters coming from the GraphQL role filter
+	Names        []string    `param:"names" db:"name"`
+	NameLike     null.String `param:"name_like" db:"name"`
+	Types        []string    `param:"types" db:"type"`
+	TypeLike     null.String `param:"type_like" db:"type"`
+	Rank         null.Int64  `param:"rank" db:"rank"`
+	Ranks        []int64     `param:"ranks" db:"rank"`
+	CreatedAt    null.Time   `param:"created_at" db:"created_at"`
+	CreatedAtGte null.Time   `param:"created_at__gte" db:"created_at"`
+	CreatedAtLte null.Time   `param:"created_at__lte" db:"created_at"`
+	UpdatedAt    null.Time   `param:"updated_at" db:"updated_at"`
 	PaginationParam
 	QueryOption query.Option
 }
@@ -55,3 +81,148 @@ type UpdateRoleParam struct {
 	DeletedAt null.Time   `db:"deleted_at" json:"-" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
 	DeletedBy null.String `db:"deleted_by" json:"-" swaggertype:"string"`
 }
+
+func (r *Role) EncodeCursor(sortBy string) (string, error) {
+	cursor := Cursor{SortBy: sortBy, ID: r.ID}
+
+	switch strings.TrimPrefix(sortBy, "-") {
+	case "name":
+		cursor.Value = r.Name
+	case "rank":
+		cursor.Value = strconv.FormatInt(r.Rank, 10)
+	case "created_at":
+		cursor.Value = r.CreatedAt.Time.Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = r.UpdatedAt.Time.Format(time.RFC3339Nano)
+	default:
+		cursor.Value = strconv.FormatInt(r.ID, 10)
+	}
+
+	return cursor.EncodeCursor()
+}
+
+func (r *Role) ConvertToQlRole() QlRole {
+	return QlRole{
+		ID:     r.ID,
+		Name:   r.Name,
+		Type:   r.Type,
+		Rank:   int(r.Rank),
+		Status: int(r.Status),
+	}
+}
+
+func (p *QlRoleParam) ConvertToRoleParam() RoleParam {
+	param := RoleParam{}
+	if p == nil {
+		return param
+	}
+
+	if p.Name != nil {
+		param.Name = null.StringFrom(*p.Name)
+	}
+
+	if p.Type != nil {
+		param.Type = null.StringFrom(*p.Type)
+	}
+
+	if p.Page != nil {
+		param.Page = int64(*p.Page)
+	}
+
+	if p.Limit != nil {
+		param.Limit = int64(*p.Limit)
+	}
+
+	return param
+}
+
+func (p *QlCreateRoleParam) ConvertToCreateRoleParam() CreateRoleParam {
+	return CreateRoleParam{
+		Name: p.Name,
+		Type: p.Type,
+		Rank: int64(p.Rank),
+	}
+}
+
+func (p *QlUpdateRoleParam) ConvertToUpdateRoleParam() UpdateRoleParam {
+	param := UpdateRoleParam{}
+
+	if p.Name != nil {
+		param.Name = *p.Name
+	}
+
+	if p.Type != nil {
+		param.Type = *p.Type
+	}
+
+	if p.Rank != nil {
+		param.Rank = int64(*p.Rank)
+	}
+
+	return param
+}
+
+func ConvertToQlRoleConnection(roles []Role, pg *Pagination) (QlRoleConnection, error) {
+	result := QlRoleConnection{
+		Edges:    []QlRoleEdge{},
+		PageInfo: pg.ConvertToQlPageInfo(),
+	}
+
+	sortBy := pg.CursorSortBy()
+	for i := range roles {
+		cursor, err := roles[i].EncodeCursor(sortBy)
+		if err != nil {
+			return result, err
+		}
+
+		node := roles[i].ConvertToQlRole()
+		result.Edges = append(result.Edges, QlRoleEdge{
+			Cursor: cursor,
+			Node:   &node,
+		})
+	}
+
+	return result, nil
+}
+
+var qlRoleSortFields = map[QlRoleSortField]string{
+	QlRoleSortFieldID:        "id",
+	QlRoleSortFieldName:      "name",
+	QlRoleSortFieldRank:      "rank",
+	QlRoleSortFieldCreatedAt: "created_at",
+	QlRoleSortFieldUpdatedAt: "updated_at",
+}
+
+func (f *QlRoleFilter) ApplyToRoleParam(param *RoleParam) error {
+	if f == nil {
+		return nil
+	}
+
+	f.ID.apply(&param.ID, &param.IDs)
+	f.Rank.apply(&param.Rank, &param.Ranks)
+
+	if err := f.Name.apply(&param.Name, &param.Names, &param.NameLike); err != nil {
+		return err
+	}
+
+	if err := f.Type.apply(&param.Type, &param.Types, &param.TypeLike); err != nil {
+		return err
+	}
+
+	f.CreatedAt.apply(&param.CreatedAtGte, &param.CreatedAtLte)
+
+	return nil
+}
+
+func ConvertQlRoleOrderBy(orderBy []QlRoleOrderBy) ([]string, error) {
+	sortBy := []string{}
+	for _, o := range orderBy {
+		key, ok := qlRoleSortFields[o.Field]
+		if !ok {
+			return nil, errors.NewWithCode(codes.CodeBadRequest, "sort by %s is not supported", o.Field)
+		}
+		sortBy = append(sortBy, convertSortDirection(key, o.Direction))
+	}
+
+	return sortBy, nil
+}
@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+)
+
+// MarshalID writes int64 ids as strings, javascript clients lose precision above 2^53
+func MarshalID(id int64) graphql.Marshaler {
+	return graphql.MarshalString(strconv.FormatInt(id, 10))
+}
+
+// UnmarshalID accepts both the string and the numeric form of an id
+func UnmarshalID(v interface{}) (int64, error) {
+	id, err := graphql.UnmarshalInt64(v)
+	if err != nil {
+		return 0, errors.NewWithCode(codes.CodeBadRequest, "invalid id %v", v)
+	}
+
+	return id, nil
+}
+
+func MarshalNullInt64(v null.Int64) graphql.Marshaler {
+	if !v.Valid {
+		return graphql.Null
+	}
+
+	return graphql.MarshalInt64(v.Int64)
+}
+
+func UnmarshalNullInt64(v interface{}) (null.Int64, error) {
+	if v == nil {
+		return null.Int64{}, nil
+	}
+
+	i, err := graphql.UnmarshalInt64(v)
+	if err != nil {
+		return null.Int64{}, errors.NewWithCode(codes.CodeBadRequest, "invalid int64 %v", v)
+	}
+
+	return null.Int64From(i), nil
+}
+
+func MarshalNullString(v null.String) graphql.Marshaler {
+	if !v.Valid {
+		return graphql.Null
+	}
+
+	return graphql.MarshalString(v.String)
+}
+
+func UnmarshalNullString(v interface{}) (null.String, error) {
+	if v == nil {
+		return null.String{}, nil
+	}
+
+	s, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(s), nil
+}
+
+// MarshalTime writes times in RFC3339
+func MarshalTime(t time.Time) graphql.Marshaler {
+	return graphql.MarshalString(t.Format(time.RFC3339))
+}
+
+func UnmarshalTime(v interface{}) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, errors.NewWithCode(codes.CodeBadRequest, "time must be a RFC3339 string")
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.NewWithCode(codes.CodeBadRequest, "invalid time %s, expected RFC3339", s)
+	}
+
+	return t, nil
+}
+
+func MarshalNullTime(v null.Time) graphql.Marshaler {
+	if !v.Valid {
+		return graphql.Null
+	}
+
+	return MarshalTime(v.Time)
+}
+
+func UnmarshalNullTime(v interface{}) (null.Time, error) {
+	if v == nil {
+		return null.Time{}, nil
+	}
+
+	t, err := UnmarshalTime(v)
+	if err != nil {
+		return null.Time{}, err
+	}
+
+	return null.TimeFrom(t), nil
+}
+
+func MarshalEmail(email string) graphql.Marshaler {
+	return graphql.MarshalString(email)
+}
+
+// UnmarshalEmail validates the address while the input is parsed, so resolvers only ever see
+// a bare address like user@example.com. Display names such as "User <user@example.com>" are rejected.
+func UnmarshalEmail(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.NewWithCode(codes.CodeBadRequest, "email must be a string")
+	}
+
+	s = strings.TrimSpace(s)
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return "", errors.NewWithCode(codes.CodeBadRequest, "invalid email address %q", s)
+	}
+
+	return s, nil
+}
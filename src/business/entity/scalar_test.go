@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/stretchr/testify/assert"
+)
+
+// marshal writes the scalar the way it is sent to the client
+func marshal(m graphql.Marshaler) string {
+	buf := bytes.Buffer{}
+	m.MarshalGQL(&buf)
+	return buf.String()
+}
+
+func Test_ID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{name: "string", input: "42", want: 42},
+		{name: "number", input: 42, want: 42},
+		{name: "json number", input: json.Number("9007199254740993"), want: 9007199254740993},
+		{name: "not a number", input: "abc", wantErr: true},
+		{name: "null", input: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalID(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// ids above 2^53 are sent as strings so javascript keeps every digit
+	assert.Equal(t, `"9007199254740993"`, marshal(MarshalID(9007199254740993)))
+}
+
+func Test_NullInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    null.Int64
+		wantErr bool
+	}{
+		{name: "number", input: 7, want: null.Int64From(7)},
+		{name: "numeric string", input: "7", want: null.Int64From(7)},
+		{name: "null", input: nil, want: null.Int64{}},
+		{name: "not a number", input: "seven", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalNullInt64(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalNullInt64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "7", marshal(MarshalNullInt64(null.Int64From(7))))
+	assert.Equal(t, "null", marshal(MarshalNullInt64(null.Int64{})))
+}
+
+func Test_NullString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    null.String
+		wantErr bool
+	}{
+		{name: "string", input: "admin", want: null.StringFrom("admin")},
+		{name: "empty string is a value", input: "", want: null.StringFrom("")},
+		{name: "null", input: nil, want: null.String{}},
+		{name: "object", input: map[string]interface{}{"name": "admin"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalNullString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalNullString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	assert.Equal(t, `"admin"`, marshal(MarshalNullString(null.StringFrom("admin"))))
+	assert.Equal(t, "null", marshal(MarshalNullString(null.String{})))
+}
+
+func Test_Time(t *testing.T) {
+	at := time.Date(2022, 6, 21, 10, 32, 29, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    null.Time
+		wantErr bool
+	}{
+		{name: "RFC3339", input: "2022-06-21T10:32:29Z", want: null.TimeFrom(at)},
+		{name: "RFC3339 with an offset", input: "2022-06-21T17:32:29+07:00", want: null.TimeFrom(at)},
+		{name: "null", input: nil, want: null.Time{}},
+		{name: "date only", input: "2022-06-21", wantErr: true},
+		{name: "unix timestamp", input: 1655807549, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalNullTime(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalNullTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want.Valid, got.Valid)
+			assert.True(t, tt.want.Time.Equal(got.Time))
+		})
+	}
+
+	// a non null Time is required
+	_, err := UnmarshalTime(nil)
+	assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+
+	assert.Equal(t, `"2022-06-21T10:32:29Z"`, marshal(MarshalTime(at)))
+	assert.Equal(t, `"2022-06-21T10:32:29Z"`, marshal(MarshalNullTime(null.TimeFrom(at))))
+	assert.Equal(t, "null", marshal(MarshalNullTime(null.Time{})))
+}
+
+func Test_Email(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "address", input: "user@example.com", want: "user@example.com"},
+		{name: "surrounding spaces are trimmed", input: "  user@example.com ", want: "user@example.com"},
+		{name: "display name", input: "User <user@example.com>", wantErr: true},
+		{name: "not an address", input: "not an email", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "not a string", input: 42, wantErr: true},
+		{name: "null", input: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalEmail(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, `"user@example.com"`, marshal(MarshalEmail("user@example.com")))
+}
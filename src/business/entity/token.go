@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken is how opaque tokens are stored, only their holder knows the token itself.
+// Refresh and verification tokens are both looked up by it.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
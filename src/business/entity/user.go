@@ -1,11 +1,57 @@
 package entity
 
 import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
 	"github.com/adiatma85/own-go-sdk/null"
 	"github.com/adiatma85/own-go-sdk/query"
 )
 
+const (
+	// User Event Topic Enum, published after a user is changed
+	TopicUserCreated       = "user.created"
+	TopicUserUpdated       = "user.updated"
+	TopicUserStatusChanged = "user.status_changed"
+)
+
+const (
+	// User Status Enum, pending users have not verified their email yet
+	UserStatusActive   = 1
+	UserStatusInactive = 0
+	UserStatusDeleted  = -1
+	UserStatusPending  = 2
+)
+
+const (
+	// AvatarMaxSize is the largest accepted avatar, 2 MiB
+	AvatarMaxSize int64 = 2 << 20
+)
+
+// AvatarContentTypes maps the accepted avatar content types to their file extension
+var AvatarContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// UserSortColumns are the sort keys allowed for user lists
+var UserSortColumns = map[string]SortColumn{
+	"id":           {Column: "id", Kind: SortKindInt},
+	"email":        {Column: "email", Kind: SortKindString},
+	"username":     {Column: "username", Kind: SortKindString},
+	"display_name": {Column: "display_name", Kind: SortKindString},
+	"created_at":   {Column: "created_at", Kind: SortKindTime},
+	"updated_at":   {Column: "updated_at", Kind: SortKindTime},
+}
+
 type User struct {
 	ID          int64       `db:"id" json:"id"`
 	RoleId      null.Int64  `db:"fk_role_id" json:"roleId"`
@@ -13,6 +59,7 @@ type User struct {
 	Username    string      `db:"username" json:"username"`
 	Password    string      `db:"password" json:"-"`
 	DisplayName string      `db:"display_name" json:"displayName"`
+	Avatar      null.String `db:"avatar" json:"avatar" swaggertype:"string"`
 	Status      null.Int64  `db:"status" json:"status" swaggertype:"integer"`
 	CreatedAt   null.Time   `db:"created_at" json:"createdAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
 	CreatedBy   null.String `db:"created_by" json:"createdBy" swaggertype:"string"`
@@ -31,6 +78,61 @@ func (u *User) ConvertToAuthUser() jwtAuth.User {
 	}
 }
 
+func (u *User) EncodeCursor(sortBy string) (string, error) {
+	cursor := Cursor{SortBy: sortBy, ID: u.ID}
+
+	switch strings.TrimPrefix(sortBy, "-") {
+	case "email":
+		cursor.Value = u.Email
+	case "username":
+		cursor.Value = u.Username
+	case "display_name":
+		cursor.Value = u.DisplayName
+	case "created_at":
+		cursor.Value = u.CreatedAt.Time.Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = u.UpdatedAt.Time.Format(time.RFC3339Nano)
+	default:
+		cursor.Value = strconv.FormatInt(u.ID, 10)
+	}
+
+	return cursor.EncodeCursor()
+}
+
+func (u *User) ConvertToQlUser() QlUser {
+	return QlUser{
+		ID:          u.ID,
+		Roleid:      u.RoleId.Int64,
+		Email:       u.Email,
+		Username:    u.Username,
+		Displayname: u.DisplayName,
+		Avatar:      u.Avatar,
+		Status:      u.Status,
+		CreatedAt:   u.CreatedAt,
+		CreatedBy:   u.CreatedBy,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
+// QlUser is bound by gqlgen instead of generated, so the audit fields keep their null types
+// and are written as null by the scalar marshalers when they are not set
+type QlUser struct {
+	ID          int64       `json:"id"`
+	Roleid      int64       `json:"roleid"`
+	Email       string      `json:"email"`
+	Username    string      `json:"username"`
+	Displayname string      `json:"displayname"`
+	Avatar      null.String `json:"-"`
+	Status      null.Int64  `json:"status"`
+	CreatedAt   null.Time   `json:"createdAt"`
+	CreatedBy   null.String `json:"createdBy"`
+	UpdatedAt   null.Time   `json:"updatedAt"`
+	Role        *QlRole     `json:"role,omitempty"`
+}
+
+// IsEntity marks QlUser as a federation entity keyed by id
+func (QlUser) IsEntity() {}
+
 type UserParam struct {
 	ID          null.Int64  `param:"id" uri:"user_id" db:"id" form:"id"`
 	RoleId      null.Int64  `param:"fk_role_id" uri:"role_id" db:"fk_role_id" form:"fk_role_id"`
@@ -38,6 +140,19 @@ type UserParam struct {
 	Email       null.String `param:"email" db:"email"`
 	Username    null.String `param:"username" db:"username"`
 	DisplayName null.String `param:"display_name" db:"display_name"`
+	Status      null.Int64  `param:"status" db:"status"`
+	// Filters coming from the GraphQL user filter
+	RoleIds         []int64     `param:"fk_role_ids" db:"fk_role_id"`
+	Emails          []string    `param:"emails" db:"email"`
+	EmailLike       null.String `param:"email_like" db:"email"`
+	Usernames       []string    `param:"usernames" db:"username"`
+	UsernameLike    null.String `param:"username_like" db:"username"`
+	DisplayNames    []string    `param:"display_names" db:"display_name"`
+	DisplayNameLike null.String `param:"display_name_like" db:"display_name"`
+	CreatedAt       null.Time   `param:"created_at" db:"created_at"`
+	CreatedAtGte    null.Time   `param:"created_at__gte" db:"created_at"`
+	CreatedAtLte    null.Time   `param:"created_at__lte" db:"created_at"`
+	UpdatedAt       null.Time   `param:"updated_at" db:"updated_at"`
 	PaginationParam
 	QueryOption query.Option
 }
@@ -49,6 +164,7 @@ type CreateUserParam struct {
 	Password        string      `db:"password" json:"password"`
 	ConfirmPassword string      `db:"-" json:"confirmPassword"`
 	DisplayName     string      `db:"display_name" json:"displayName"`
+	Status          int64       `db:"status" json:"-"`
 	CreatedBy       null.String `json:"-" db:"created_by" swaggertype:"string"`
 	UpdatedBy       null.String `json:"-" db:"updated_by" swaggertype:"string"`
 }
@@ -58,6 +174,7 @@ type UpdateUserParam struct {
 	Username    string      `param:"username" db:"username" json:"username"`
 	DisplayName string      `param:"display_name" db:"display_name" json:"displayName"`
 	Password    string      `param:"password" db:"password" json:"-"`
+	Avatar      null.String `param:"avatar" db:"avatar" json:"-" swaggertype:"string"`
 	Status      null.Int64  `param:"status" db:"status" json:"-" swaggertype:"integer"`
 	UpdatedAt   null.Time   `param:"updated_at" db:"updated_at" json:"-" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
 	UpdatedBy   null.String `param:"updated_by" db:"updated_by" json:"-" swaggertype:"string"`
@@ -65,6 +182,13 @@ type UpdateUserParam struct {
 	DeletedBy   null.String `param:"deleted_by" db:"deleted_by" json:"-" swaggertype:"string"`
 }
 
+// UploadAvatarParam is the raw avatar, its content type is sniffed from the file rather than trusted
+type UploadAvatarParam struct {
+	Filename string
+	Size     int64
+	File     io.ReadSeeker
+}
+
 type UserLoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -77,8 +201,195 @@ type UserLoginResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+type VerifyEmailParam struct {
+	Token string `json:"token"`
+}
+
+type ResendVerificationParam struct {
+	Email string `json:"email"`
+}
+
+type UserRefreshTokenParam struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken           string    `json:"accessToken"`
+	RefreshToken          string    `json:"refreshToken"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+}
+
 type ChangePasswordRequest struct {
 	OldPassword     string `db:"-" json:"oldPassword"`
 	Password        string `db:"-" json:"newPassword"`
 	ConfirmPassword string `db:"-" json:"confirmPassword"`
 }
+
+func (p *QlUserParam) ConvertToUserParam() UserParam {
+	param := UserParam{}
+	if p == nil {
+		return param
+	}
+
+	if p.Email != nil {
+		param.Email = null.StringFrom(*p.Email)
+	}
+
+	if p.Username != nil {
+		param.Username = null.StringFrom(*p.Username)
+	}
+
+	if p.DisplayName != nil {
+		param.DisplayName = null.StringFrom(*p.DisplayName)
+	}
+
+	if p.RoleID != nil {
+		param.RoleId = null.Int64From(*p.RoleID)
+	}
+
+	if p.Page != nil {
+		param.Page = int64(*p.Page)
+	}
+
+	if p.Limit != nil {
+		param.Limit = int64(*p.Limit)
+	}
+
+	return param
+}
+
+func (p *QlCreateUserAdminParam) ConvertToCreateUserParam() CreateUserParam {
+	return CreateUserParam{
+		RoleId:      p.RoleID,
+		Email:       p.Email,
+		Username:    p.Username,
+		Password:    p.Password,
+		DisplayName: p.DisplayName,
+	}
+}
+
+func (p *QlUpdateUserParam) ConvertToUpdateUserParam() UpdateUserParam {
+	param := UpdateUserParam{}
+
+	if p.RoleID != nil {
+		param.RoleId = strconv.FormatInt(*p.RoleID, 10)
+	}
+
+	if p.Username != nil {
+		param.Username = *p.Username
+	}
+
+	if p.DisplayName != nil {
+		param.DisplayName = *p.DisplayName
+	}
+
+	return param
+}
+
+func (p *QlUpdateSelfParam) ConvertToUpdateUserParam() UpdateUserParam {
+	param := UpdateUserParam{}
+
+	if p.Username != nil {
+		param.Username = *p.Username
+	}
+
+	if p.DisplayName != nil {
+		param.DisplayName = *p.DisplayName
+	}
+
+	return param
+}
+
+func (p *QlChangePasswordParam) ConvertToChangePasswordRequest() ChangePasswordRequest {
+	return ChangePasswordRequest{
+		OldPassword:     p.OldPassword,
+		Password:        p.NewPassword,
+		ConfirmPassword: p.ConfirmPassword,
+	}
+}
+
+func (r *RefreshTokenResponse) ConvertToQlRefreshTokenResponse() QlRefreshTokenResponse {
+	return QlRefreshTokenResponse{
+		AccessToken:           r.AccessToken,
+		RefreshToken:          r.RefreshToken,
+		RefreshTokenExpiresAt: null.TimeFrom(r.RefreshTokenExpiresAt),
+	}
+}
+
+func ConvertToQlUserConnection(users []User, pg *Pagination) (QlUserConnection, error) {
+	result := QlUserConnection{
+		Edges:    []QlUserEdge{},
+		PageInfo: pg.ConvertToQlPageInfo(),
+	}
+
+	sortBy := pg.CursorSortBy()
+	for i := range users {
+		cursor, err := users[i].EncodeCursor(sortBy)
+		if err != nil {
+			return result, err
+		}
+
+		node := users[i].ConvertToQlUser()
+		result.Edges = append(result.Edges, QlUserEdge{
+			Cursor: cursor,
+			Node:   &node,
+		})
+	}
+
+	return result, nil
+}
+
+var qlUserSortFields = map[QlUserSortField]string{
+	QlUserSortFieldID:          "id",
+	QlUserSortFieldEmail:       "email",
+	QlUserSortFieldUsername:    "username",
+	QlUserSortFieldDisplayName: "display_name",
+	QlUserSortFieldCreatedAt:   "created_at",
+	QlUserSortFieldUpdatedAt:   "updated_at",
+}
+
+func (f *QlUserFilter) ApplyToUserParam(param *UserParam) error {
+	if f == nil {
+		return nil
+	}
+
+	f.ID.apply(&param.ID, &param.IDs)
+	f.RoleID.apply(&param.RoleId, &param.RoleIds)
+
+	if err := f.Email.apply(&param.Email, &param.Emails, &param.EmailLike); err != nil {
+		return err
+	}
+
+	if err := f.Username.apply(&param.Username, &param.Usernames, &param.UsernameLike); err != nil {
+		return err
+	}
+
+	if err := f.DisplayName.apply(&param.DisplayName, &param.DisplayNames, &param.DisplayNameLike); err != nil {
+		return err
+	}
+
+	f.CreatedAt.apply(&param.CreatedAtGte, &param.CreatedAtLte)
+
+	return nil
+}
+
+func ConvertQlUserOrderBy(orderBy []QlUserOrderBy) ([]string, error) {
+	sortBy := []string{}
+	for _, o := range orderBy {
+		key, ok := qlUserSortFields[o.Field]
+		if !ok {
+			return nil, errors.NewWithCode(codes.CodeBadRequest, "sort by %s is not supported", o.Field)
+		}
+		sortBy = append(sortBy, convertSortDirection(key, o.Direction))
+	}
+
+	return sortBy, nil
+}
+
+func ConvertToUploadAvatarParam(file graphql.Upload) UploadAvatarParam {
+	return UploadAvatarParam{
+		Filename: file.Filename,
+		Size:     file.Size,
+		File:     file.File,
+	}
+}
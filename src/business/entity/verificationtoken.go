@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/query"
+)
+
+const (
+	// Verification Token Status Enum
+	VerificationTokenStatusActive  = 1
+	VerificationTokenStatusUsed    = 0
+	VerificationTokenStatusRevoked = -1
+)
+
+// VerificationToken proves the ownership of the email of a user, only the hash of the token is stored
+type VerificationToken struct {
+	ID        int64       `db:"id" json:"id"`
+	UserID    int64       `db:"fk_user_id" json:"userId"`
+	TokenHash string      `db:"token_hash" json:"-"`
+	ExpiresAt null.Time   `db:"expires_at" json:"expiresAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
+	UsedAt    null.Time   `db:"used_at" json:"usedAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
+	RevokedAt null.Time   `db:"revoked_at" json:"revokedAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
+	Status    int64       `db:"status" json:"status" swaggertype:"integer"`
+	CreatedAt null.Time   `db:"created_at" json:"createdAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
+	CreatedBy null.String `db:"created_by" json:"createdBy" swaggertype:"string"`
+	UpdatedAt null.Time   `db:"updated_at" json:"updatedAt" swaggertype:"string" example:"2022-06-21T10:32:29Z"`
+	UpdatedBy null.String `db:"updated_by" json:"updatedBy" swaggertype:"string"`
+}
+
+type VerificationTokenParam struct {
+	ID        null.Int64  `param:"id" db:"id"`
+	UserID    null.Int64  `param:"fk_user_id" db:"fk_user_id"`
+	TokenHash null.String `param:"token_hash" db:"token_hash"`
+	// ExpiresAtGt leaves out the tokens that expired
+	ExpiresAtGt null.Time `param:"expires_at__gt" db:"expires_at"`
+	PaginationParam
+	QueryOption query.Option
+}
+
+type CreateVerificationTokenParam struct {
+	UserID    int64       `db:"fk_user_id"`
+	TokenHash string      `db:"token_hash"`
+	ExpiresAt null.Time   `db:"expires_at"`
+	CreatedBy null.String `db:"created_by"`
+}
+
+type UpdateVerificationTokenParam struct {
+	Status    null.Int64  `param:"status" db:"status"`
+	UsedAt    null.Time   `param:"used_at" db:"used_at"`
+	RevokedAt null.Time   `param:"revoked_at" db:"revoked_at"`
+	UpdatedAt null.Time   `param:"updated_at" db:"updated_at"`
+	UpdatedBy null.String `param:"updated_by" db:"updated_by"`
+}
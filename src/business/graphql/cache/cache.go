@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/redis"
+)
+
+const (
+	// Cache Type Enum
+	TypeLRU   = "lru"
+	TypeRedis = "redis"
+
+	defaultLRUSize = 100
+)
+
+type InitParam struct {
+	Type   string
+	Size   int
+	TTL    time.Duration
+	Prefix string
+	Log    log.Interface
+	Redis  redis.Interface
+}
+
+// Init returns the cache of the given type, the in-process LRU is used unless redis is
+// configured and available. Values are stored as strings so they can be shared by instances.
+func Init(param InitParam) graphql.Cache {
+	if param.Type == TypeRedis && param.Redis != nil {
+		return &redisCache{
+			log:    param.Log,
+			redis:  param.Redis,
+			prefix: param.Prefix,
+			ttl:    param.TTL,
+		}
+	}
+
+	size := param.Size
+	if size < 1 {
+		size = defaultLRUSize
+	}
+
+	return lru.New(size)
+}
+
+type redisCache struct {
+	log    log.Interface
+	redis  redis.Interface
+	prefix string
+	ttl    time.Duration
+}
+
+func (r *redisCache) Get(ctx context.Context, key string) (interface{}, bool) {
+	value, err := r.redis.Get(ctx, r.prefix+key)
+	if err != nil {
+		// a miss and an unreachable redis are handled the same, the client resends the full query
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (r *redisCache) Add(ctx context.Context, key string, value interface{}) {
+	if err := r.redis.SetEX(ctx, r.prefix+key, fmt.Sprint(value), r.ttl); err != nil {
+		r.log.Error(ctx, err)
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/redis"
+)
+
+// Store keeps strings like graphql.Cache, but every value chooses how long it lives
+type Store interface {
+	Get(ctx context.Context, key string) (string, bool)
+	Set(ctx context.Context, key string, value string, ttl time.Duration)
+}
+
+// InitStore returns the store of the given type, the TTL of the param is unused. A redis store without
+// a redis connection is fatal, every replica would otherwise keep and invalidate responses on its own.
+func InitStore(param InitParam) Store {
+	if param.Type == TypeRedis {
+		if param.Redis == nil {
+			param.Log.Fatal(context.Background(), fmt.Sprintf("[FATAL] %s store requires a redis connection", param.Prefix))
+		}
+
+		return &redisStore{
+			log:    param.Log,
+			redis:  param.Redis,
+			prefix: param.Prefix,
+		}
+	}
+
+	size := param.Size
+	if size < 1 {
+		size = defaultLRUSize
+	}
+
+	return &memoryStore{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+type redisStore struct {
+	log    log.Interface
+	redis  redis.Interface
+	prefix string
+}
+
+func (r *redisStore) Get(ctx context.Context, key string) (string, bool) {
+	value, err := r.redis.Get(ctx, r.prefix+key)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+func (r *redisStore) Set(ctx context.Context, key string, value string, ttl time.Duration) {
+	if err := r.redis.SetEX(ctx, r.prefix+key, value, ttl); err != nil {
+		r.log.Error(ctx, err)
+	}
+}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+// memoryStore is an LRU whose entries also expire
+type memoryStore struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func (m *memoryStore) Get(ctx context.Context, key string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+		return "", false
+	}
+
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (m *memoryStore) Set(ctx context.Context, key string, value string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value = &memoryEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+)
+
+// defaultListLimit mirrors the default page size of the sdk query builder
+const defaultListLimit = 10
+
+// NewComplexityRoot weighs every list field by the amount of rows it may return,
+// so the complexity limit grows with the requested limit instead of the field count
+func NewComplexityRoot() ComplexityRoot {
+	c := ComplexityRoot{}
+
+	c.Query.Users = func(childComplexity int, param *entity.QlUserParam, filter *entity.QlUserFilter, orderBy []entity.QlUserOrderBy) int {
+		var limit *int
+		if param != nil {
+			limit = param.Limit
+		}
+		return listComplexity(childComplexity, limit, defaultListLimit)
+	}
+
+	c.Query.UsersConnection = func(childComplexity int, first *int, after *string, param *entity.QlUserParam, filter *entity.QlUserFilter, orderBy *entity.QlUserOrderBy) int {
+		return listComplexity(childComplexity, first, entity.DefaultCursorLimit)
+	}
+
+	c.Query.Roles = func(childComplexity int, param *entity.QlRoleParam, filter *entity.QlRoleFilter, orderBy []entity.QlRoleOrderBy) int {
+		var limit *int
+		if param != nil {
+			limit = param.Limit
+		}
+		return listComplexity(childComplexity, limit, defaultListLimit)
+	}
+
+	c.Query.RolesConnection = func(childComplexity int, first *int, after *string, param *entity.QlRoleParam, filter *entity.QlRoleFilter, orderBy *entity.QlRoleOrderBy) int {
+		return listComplexity(childComplexity, first, entity.DefaultCursorLimit)
+	}
+
+	return c
+}
+
+func listComplexity(childComplexity int, limit *int, defaultLimit int) int {
+	rows := defaultLimit
+	if limit != nil && *limit > 0 {
+		rows = *limit
+	}
+
+	return 1 + childComplexity*rows
+}
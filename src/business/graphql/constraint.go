@@ -0,0 +1,188 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// Constraint Format Enum
+	ConstraintFormatEmail = "email"
+)
+
+type constraintCtxKey struct{}
+
+// FieldViolation is a single failed constraint of an input, it is listed in extensions.fields
+type FieldViolation struct {
+	Field      string `json:"field"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
+}
+
+// constraintViolations collects the violations of every field of an operation. Arguments of a
+// field are parsed under its own field context, so the violations are grouped by that context.
+type constraintViolations struct {
+	mu     sync.Mutex
+	fields map[*graphql.FieldContext][]FieldViolation
+}
+
+// ConstraintValidation reports every @constraint violation of a field at once, before its
+// resolver runs. Without it the first violation fails the input on its own.
+type ConstraintValidation struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = ConstraintValidation{}
+
+func (ConstraintValidation) ExtensionName() string {
+	return "ConstraintValidation"
+}
+
+func (ConstraintValidation) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (ConstraintValidation) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	return next(context.WithValue(ctx, constraintCtxKey{}, &constraintViolations{
+		fields: map[*graphql.FieldContext][]FieldViolation{},
+	}))
+}
+
+func (ConstraintValidation) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	violations, ok := ctx.Value(constraintCtxKey{}).(*constraintViolations)
+	if !ok {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	violations.mu.Lock()
+	fields := violations.fields[fc]
+	delete(violations.fields, fc)
+	violations.mu.Unlock()
+
+	if len(fields) > 0 {
+		return nil, constraintError(fields)
+	}
+
+	return next(ctx)
+}
+
+func constraintError(fields []FieldViolation) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: "input validation failed",
+		Err:     errors.NewWithCode(codes.CodeBadRequest, "input validation failed"),
+		Extensions: map[string]interface{}{
+			"fields": fields,
+		},
+	}
+}
+
+var constraintPatterns sync.Map
+
+func (d *directive) constraint(ctx context.Context, obj interface{}, next graphql.Resolver, minLength *int, maxLength *int, pattern *string, format *string) (interface{}, error) {
+	value, err := next(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case *string:
+		if v == nil {
+			return value, nil
+		}
+		s = *v
+	default:
+		return value, errors.NewWithCode(codes.CodeNotImplemented, "constraint is only supported on strings, got %T", value)
+	}
+
+	violations := []FieldViolation{}
+	field := constraintFieldName(ctx)
+
+	if minLength != nil && utf8.RuneCountInString(s) < *minLength {
+		message := fmt.Sprintf("must be at least %d characters", *minLength)
+		if *minLength == 1 {
+			message = "must not be empty"
+		}
+		violations = append(violations, FieldViolation{Field: field, Constraint: "minLength", Message: message})
+	}
+
+	if maxLength != nil && utf8.RuneCountInString(s) > *maxLength {
+		violations = append(violations, FieldViolation{Field: field, Constraint: "maxLength", Message: fmt.Sprintf("must be at most %d characters", *maxLength)})
+	}
+
+	if pattern != nil {
+		re, err := compileConstraintPattern(*pattern)
+		if err != nil {
+			return value, err
+		}
+
+		if !re.MatchString(s) {
+			violations = append(violations, FieldViolation{Field: field, Constraint: "pattern", Message: fmt.Sprintf("must match %s", *pattern)})
+		}
+	}
+
+	if format != nil {
+		switch *format {
+		case ConstraintFormatEmail:
+			if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+				violations = append(violations, FieldViolation{Field: field, Constraint: "format", Message: "must be a valid email address"})
+			}
+		default:
+			return value, errors.NewWithCode(codes.CodeNotImplemented, "constraint format %s is not supported", *format)
+		}
+	}
+
+	if len(violations) == 0 {
+		return value, nil
+	}
+
+	collector, ok := ctx.Value(constraintCtxKey{}).(*constraintViolations)
+	if !ok {
+		return value, constraintError(violations)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	collector.mu.Lock()
+	collector.fields[fc] = append(collector.fields[fc], violations...)
+	collector.mu.Unlock()
+
+	return value, nil
+}
+
+// constraintFieldName is the path of the input relative to the field its argument belongs to, e.g. input.email
+func constraintFieldName(ctx context.Context) string {
+	path := graphql.GetPath(ctx)
+	if fc := graphql.GetFieldContext(ctx); fc != nil {
+		path = path[len(fc.Path()):]
+	}
+
+	return path.String()
+}
+
+func compileConstraintPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := constraintPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeNotImplemented, "invalid constraint pattern %s", pattern)
+	}
+	constraintPatterns.Store(pattern, re)
+
+	return re, nil
+}
@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConstraintValidation(t *testing.T) {
+	exec := executor.New(NewExecutableSchema(Config{
+		Resolvers:  &Resolver{},
+		Directives: NewDirectiveRoot(nil, nil),
+		Complexity: NewComplexityRoot(),
+	}))
+	exec.Use(ConstraintValidation{})
+
+	query := `mutation ($input: QlCreateUserParam!) { register(input: $input) { id } }`
+
+	tests := []struct {
+		name       string
+		input      map[string]interface{}
+		wantFields []FieldViolation
+	}{
+		{
+			name: "every violating field is reported in one error",
+			input: map[string]interface{}{
+				"email":           "user@example.com",
+				"username":        "a!",
+				"password":        "short",
+				"confirmPassword": "short",
+				"displayName":     "",
+			},
+			wantFields: []FieldViolation{
+				{Field: "input.username", Constraint: "minLength", Message: "must be at least 3 characters"},
+				{Field: "input.username", Constraint: "pattern", Message: "must match ^[A-Za-z0-9_.]+$"},
+				{Field: "input.password", Constraint: "minLength", Message: "must be at least 8 characters"},
+				{Field: "input.displayName", Constraint: "minLength", Message: "must not be empty"},
+			},
+		},
+		{
+			// the Email scalar is parsed before @constraint runs, an invalid address fails on its own
+			name: "invalid email is rejected by the scalar without fields",
+			input: map[string]interface{}{
+				"email":           "not an email",
+				"username":        "a!",
+				"password":        "password",
+				"confirmPassword": "password",
+				"displayName":     "User",
+			},
+			wantFields: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := graphql.StartOperationTrace(context.Background())
+			rc, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{
+				Query:     query,
+				Variables: map[string]interface{}{"input": tt.input},
+			})
+			if !assert.Empty(t, errs) {
+				return
+			}
+
+			responses, ctx := exec.DispatchOperation(ctx, rc)
+			resp := responses(ctx)
+
+			if !assert.Len(t, resp.Errors, 1) {
+				return
+			}
+
+			fields, _ := resp.Errors[0].Extensions["fields"].([]FieldViolation)
+			assert.ElementsMatch(t, tt.wantFields, fields)
+		})
+	}
+}
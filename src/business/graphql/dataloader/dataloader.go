@@ -0,0 +1,84 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+type contextKey string
+
+const loadersKey contextKey = "DataLoaders"
+
+// Loaders holds every batched loader of a single GraphQL request
+type Loaders struct {
+	Role *Loader[int64, entity.Role]
+
+	// the role of the caller is resolved once and shared by every directive of the request
+	authRoleOnce sync.Once
+	authRole     entity.Role
+	authRoleErr  error
+	uc           *usecase.Usecase
+	ctx          context.Context
+}
+
+// NewLoaders takes the context of the operation, batches are fetched with its values
+func NewLoaders(ctx context.Context, uc *usecase.Usecase) *Loaders {
+	return &Loaders{
+		uc:  uc,
+		ctx: context.WithoutCancel(ctx),
+		Role: NewLoader(ctx, func(ctx context.Context, ids []int64) (map[int64]entity.Role, error) {
+			roles, err := uc.Role.GetListByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[int64]entity.Role, len(roles))
+			for _, role := range roles {
+				result[role.ID] = role
+			}
+
+			return result, nil
+		}),
+	}
+}
+
+// LoadRole returns nil without an error when the role does not exist
+func (l *Loaders) LoadRole(ctx context.Context, id int64) (*entity.Role, error) {
+	if l == nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, "loaders are not attached to the operation")
+	}
+
+	role, err := l.Role.Load(ctx, id)
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// LoadAuthRole returns the role of the authenticated user of the operation, like a batch it is
+// loaded with the operation context so a cancelled field never fails the others
+func (l *Loaders) LoadAuthRole() (entity.Role, error) {
+	l.authRoleOnce.Do(func() {
+		l.authRole, l.authRoleErr = l.uc.Role.GetAuthRole(l.ctx)
+	})
+
+	return l.authRole, l.authRoleErr
+}
+
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey, loaders)
+}
+
+func For(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,125 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+const (
+	defaultWait     = 2 * time.Millisecond
+	defaultMaxBatch = 100
+)
+
+// FetchFunc loads every key of a batch at once, keys missing from the result are reported as not found
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested within a short window and resolves them with a single fetch.
+// Results are cached for the lifetime of the loader, which is a single request.
+type Loader[K comparable, V any] struct {
+	// ctx carries the values of the request but never its cancellation, a batch is fetched for
+	// every caller so no single caller leaving may fail the others
+	ctx      context.Context
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results map[K]*result[V]
+	closing bool
+}
+
+func NewLoader[K comparable, V any](ctx context.Context, fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:      context.WithoutCancel(ctx),
+		fetch:    fetch,
+		wait:     defaultWait,
+		maxBatch: defaultMaxBatch,
+		cache:    map[K]*result[V]{},
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		l.enqueue(key, res)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue must be called with the lock held
+func (l *Loader[K, V]) enqueue(key K, res *result[V]) {
+	if l.batch == nil {
+		l.batch = &batch[K, V]{results: map[K]*result[V]{}}
+		go l.waitAndDispatch(l.batch)
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results[key] = res
+
+	if len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		b.closing = true
+		l.batch = nil
+		go l.dispatch(b)
+	}
+}
+
+func (l *Loader[K, V]) waitAndDispatch(b *batch[K, V]) {
+	time.Sleep(l.wait)
+
+	l.mu.Lock()
+	if b.closing {
+		l.mu.Unlock()
+		return
+	}
+	b.closing = true
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	l.dispatch(b)
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	values, err := l.fetch(l.ctx, b.keys)
+
+	for _, key := range b.keys {
+		res := b.results[key]
+		if err != nil {
+			res.err = err
+		} else if value, ok := values[key]; ok {
+			res.value = value
+		} else {
+			res.err = errors.NewWithCode(codes.CodeNotFound, "key %v is not found", key)
+		}
+		close(res.done)
+	}
+}
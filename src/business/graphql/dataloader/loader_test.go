@@ -0,0 +1,106 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCtxKey string
+
+func TestLoader_Load(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int64
+	)
+
+	opCtx := context.WithValue(context.Background(), testCtxKey("operation"), "op")
+	l := NewLoader(opCtx, func(ctx context.Context, keys []int64) (map[int64]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+
+		values := map[int64]string{}
+		for _, key := range keys {
+			if key != 404 {
+				values[key] = "value"
+			}
+		}
+		return values, nil
+	})
+
+	wg := sync.WaitGroup{}
+	errs := make([]error, 3)
+	for i, key := range []int64{1, 2, 404} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = l.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, batches, 1, "keys of the window are fetched at once")
+	assert.ElementsMatch(t, []int64{1, 2, 404}, batches[0])
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.Equal(t, codes.CodeNotFound, errors.GetCode(errs[2]))
+
+	// cached keys are never fetched again
+	_, err := l.Load(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+}
+
+func TestLoader_Load_cancelledCaller(t *testing.T) {
+	fetched := make(chan context.Context, 1)
+	release := make(chan struct{})
+
+	opCtx := context.WithValue(context.Background(), testCtxKey("operation"), "op")
+	l := NewLoader(opCtx, func(ctx context.Context, keys []int64) (map[int64]string, error) {
+		fetched <- ctx
+		<-release
+
+		values := map[int64]string{}
+		for _, key := range keys {
+			values[key] = "value"
+		}
+		return values, nil
+	})
+	// wide enough for the second caller to join the batch of the first
+	l.wait = 50 * time.Millisecond
+
+	// the first caller starts the batch and leaves before it is fetched
+	firstCtx, cancel := context.WithCancel(context.WithValue(opCtx, testCtxKey("field"), "first"))
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := l.Load(firstCtx, 1)
+		firstErr <- err
+	}()
+
+	secondValue := make(chan string, 1)
+	secondErr := make(chan error, 1)
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		value, err := l.Load(context.Background(), 2)
+		secondValue <- value
+		secondErr <- err
+	}()
+
+	fetchCtx := <-fetched
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-secondErr)
+	assert.Equal(t, "value", <-secondValue)
+
+	assert.NoError(t, fetchCtx.Err(), "the fetch outlives the caller that started it")
+	assert.Equal(t, "op", fetchCtx.Value(testCtxKey("operation")))
+	assert.Nil(t, fetchCtx.Value(testCtxKey("field")), "the fetch never sees the values of a single caller")
+}
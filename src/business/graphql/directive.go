@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/dataloader"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+)
+
+type directive struct {
+	uc      *usecase.Usecase
+	jwtAuth jwtAuth.Interface
+}
+
+// NewDirectiveRoot returns the schema directives guarding fields by the identity of the caller
+func NewDirectiveRoot(uc *usecase.Usecase, jwt jwtAuth.Interface) DirectiveRoot {
+	d := &directive{
+		uc:      uc,
+		jwtAuth: jwt,
+	}
+
+	return DirectiveRoot{
+		Authenticated:  d.authenticated,
+		Constraint:     d.constraint,
+		EntityResolver: d.entityResolver,
+		HasRole:        d.hasRole,
+		MinRank:        d.minRank,
+	}
+}
+
+func (d *directive) authenticated(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, err := d.jwtAuth.GetUserAuthInfo(ctx); err != nil {
+		return nil, errors.NewWithCode(codes.CodeAuthFailure, "authentication is required")
+	}
+
+	return next(ctx)
+}
+
+func (d *directive) hasRole(ctx context.Context, obj interface{}, next graphql.Resolver, roleType entity.QlRoleType) (interface{}, error) {
+	role, err := d.authRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.ID != entity.RoleIdSuperAdmin && role.Type != strings.ToLower(string(roleType)) {
+		return nil, errors.NewWithCode(codes.CodeForbidden, "%s role is required", strings.ToLower(string(roleType)))
+	}
+
+	return next(ctx)
+}
+
+func (d *directive) minRank(ctx context.Context, obj interface{}, next graphql.Resolver, rank int) (interface{}, error) {
+	role, err := d.authRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.ID != entity.RoleIdSuperAdmin && role.Rank < int64(rank) {
+		return nil, errors.NewWithCode(codes.CodeForbidden, "role rank %d is required", rank)
+	}
+
+	return next(ctx)
+}
+
+// entityResolver only tells the federation code generation how to resolve the type,
+// it still runs on every field returning the type and must let it through
+func (d *directive) entityResolver(ctx context.Context, obj interface{}, next graphql.Resolver, multi *bool) (interface{}, error) {
+	return next(ctx)
+}
+
+func (d *directive) authRole(ctx context.Context) (entity.Role, error) {
+	if _, err := d.jwtAuth.GetUserAuthInfo(ctx); err != nil {
+		return entity.Role{}, errors.NewWithCode(codes.CodeAuthFailure, "authentication is required")
+	}
+
+	loaders := dataloader.For(ctx)
+	if loaders == nil {
+		return d.uc.Role.GetAuthRole(ctx)
+	}
+
+	return loaders.LoadAuthRole()
+}
@@ -0,0 +1,201 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/dataloader"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	mock_role "github.com/adiatma85/exp-golang-graphql/tests/mock/usecase/role"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDirective(t *testing.T) (*directive, *mock_role.MockInterface) {
+	ctrl := gomock.NewController(t)
+	role := mock_role.NewMockInterface(ctrl)
+
+	return &directive{
+		uc:      &usecase.Usecase{Role: role},
+		jwtAuth: jwtAuth.Init(jwtAuth.Config{Secret: "secret"}),
+	}, role
+}
+
+func Test_directive(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		loaders   bool
+		authRole  entity.Role
+		roleErr   error
+		call      func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error)
+		wantCode  codes.Code
+		wantErr   bool
+	}{
+		{
+			name:      "authenticated rejects an anonymous caller",
+			anonymous: true,
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.authenticated(ctx, nil, next)
+			},
+			wantCode: codes.CodeAuthFailure,
+			wantErr:  true,
+		},
+		{
+			name:      "hasRole rejects an anonymous caller without loading a role",
+			anonymous: true,
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeAdmin)
+			},
+			wantCode: codes.CodeAuthFailure,
+			wantErr:  true,
+		},
+		{
+			name:     "hasRole rejects the wrong role type",
+			authRole: entity.Role{ID: 3, Type: entity.RoleTypeUser, Rank: 100},
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeAdmin)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:     "hasRole allows the role type",
+			authRole: entity.Role{ID: 2, Type: entity.RoleTypeAdmin},
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeAdmin)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "hasRole allows the super admin of any type",
+			authRole: entity.Role{ID: entity.RoleIdSuperAdmin},
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeUser)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "hasRole denies when the role fails to load",
+			authRole: entity.Role{},
+			roleErr:  errors.NewWithCode(codes.CodeSQLRead, "connection reset"),
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeAdmin)
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+		{
+			name:     "hasRole denies when the role fails to load through the loaders",
+			loaders:  true,
+			authRole: entity.Role{},
+			roleErr:  errors.NewWithCode(codes.CodeSQLRead, "connection reset"),
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.hasRole(ctx, nil, next, entity.QlRoleTypeAdmin)
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+		{
+			name:      "entityResolver lets every caller through",
+			anonymous: true,
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.entityResolver(ctx, nil, next, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "minRank rejects an anonymous caller",
+			anonymous: true,
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.minRank(ctx, nil, next, 10)
+			},
+			wantCode: codes.CodeAuthFailure,
+			wantErr:  true,
+		},
+		{
+			name:     "minRank rejects a rank below the minimum",
+			authRole: entity.Role{ID: 2, Type: entity.RoleTypeAdmin, Rank: 9},
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.minRank(ctx, nil, next, 10)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:     "minRank allows the minimum rank",
+			loaders:  true,
+			authRole: entity.Role{ID: 2, Type: entity.RoleTypeAdmin, Rank: 10},
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.minRank(ctx, nil, next, 10)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "minRank denies when the role fails to load",
+			authRole: entity.Role{},
+			roleErr:  errors.NewWithCode(codes.CodeSQLRead, "connection reset"),
+			call: func(d *directive, ctx context.Context, next func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+				return d.minRank(ctx, nil, next, 10)
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, role := newTestDirective(t)
+
+			ctx := context.Background()
+			if !tt.anonymous {
+				ctx = d.jwtAuth.SetUserAuthInfo(ctx, jwtAuth.UserAuthParam{User: jwtAuth.User{ID: 42}})
+				if tt.authRole != (entity.Role{}) || tt.roleErr != nil {
+					role.EXPECT().GetAuthRole(gomock.Any()).Return(tt.authRole, tt.roleErr)
+				}
+			}
+			if tt.loaders {
+				ctx = dataloader.WithLoaders(ctx, dataloader.NewLoaders(ctx, d.uc))
+			}
+
+			called := false
+			got, err := tt.call(d, ctx, func(ctx context.Context) (interface{}, error) {
+				called = true
+				return "resolved", nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("directive error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				assert.False(t, called, "a denied field is never resolved")
+				assert.Nil(t, got)
+				return
+			}
+			assert.True(t, called)
+			assert.Equal(t, "resolved", got)
+		})
+	}
+}
+
+func Test_schema_roleManagementRank(t *testing.T) {
+	mutation := NewExecutableSchema(Config{}).Schema().Mutation
+
+	// every field changing the roles requires both an admin and its rank
+	for _, name := range []string{"createRole", "updateRole", "deleteRole", "activateRole"} {
+		field := mutation.Fields.ForName(name)
+		if !assert.NotNil(t, field, name) {
+			continue
+		}
+
+		assert.NotNil(t, field.Directives.ForName("hasRole"), name)
+		minRank := field.Directives.ForName("minRank")
+		if assert.NotNil(t, minRank, name) {
+			assert.Equal(t, "100", minRank.Arguments.ForName("rank").Value.Raw, name)
+		}
+	}
+}
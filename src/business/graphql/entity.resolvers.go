@@ -0,0 +1,44 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+)
+
+// FindManyQlUserByIDs is the resolver for the findManyQlUserByIDs field.
+func (r *entityResolver) FindManyQlUserByIDs(ctx context.Context, reps []*entity.QlUserByIDsInput) ([]*entity.QlUser, error) {
+	ids := make([]int64, 0, len(reps))
+	for _, rep := range reps {
+		ids = append(ids, rep.ID)
+	}
+
+	users, err := r.Uc.User.GetListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[int64]entity.QlUser{}
+	for _, user := range users {
+		byID[user.ID] = user.ConvertToQlUser()
+	}
+
+	// results follow the order of the representations, unknown or inactive users resolve to null
+	results := make([]*entity.QlUser, len(reps))
+	for i, rep := range reps {
+		if user, ok := byID[rep.ID]; ok {
+			results[i] = &user
+		}
+	}
+
+	return results, nil
+}
+
+// Entity returns EntityResolver implementation.
+func (r *Resolver) Entity() EntityResolver { return &entityResolver{r} }
+
+type entityResolver struct{ *Resolver }
@@ -7,7 +7,9 @@ package graphql
 import (
 	"context"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/null"
 )
 
 // Login is the resolver for the login field.
@@ -35,13 +37,188 @@ func (r *mutationResolver) Register(ctx context.Context, input entity.QlCreateUs
 		return entity.QlUser{}, err
 	}
 
-	return entity.QlUser{
-		ID:          int(user.ID),
-		Roleid:      int(user.RoleId.Int64),
-		Email:       user.Email,
-		Username:    input.Username,
-		Displayname: user.DisplayName,
-	}, nil
+	return user.ConvertToQlUser(), nil
+}
+
+// VerifyEmail is the resolver for the verifyEmail field.
+func (r *mutationResolver) VerifyEmail(ctx context.Context, token string) (bool, error) {
+	if err := r.Uc.User.VerifyEmail(ctx, entity.VerifyEmailParam{Token: token}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ResendVerification is the resolver for the resendVerification field.
+func (r *mutationResolver) ResendVerification(ctx context.Context, email string) (bool, error) {
+	if err := r.Uc.User.ResendVerification(ctx, entity.ResendVerificationParam{Email: email}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateMe is the resolver for the updateMe field.
+func (r *mutationResolver) UpdateMe(ctx context.Context, input entity.QlUpdateSelfParam) (bool, error) {
+	if err := r.Uc.User.UpdateUserSelfProfile(ctx, input.ConvertToUpdateUserParam()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UploadAvatar is the resolver for the uploadAvatar field.
+func (r *mutationResolver) UploadAvatar(ctx context.Context, file graphql.Upload) (entity.QlUser, error) {
+	user, err := r.Uc.User.UploadAvatar(ctx, entity.ConvertToUploadAvatarParam(file))
+	if err != nil {
+		return entity.QlUser{}, err
+	}
+
+	return user.ConvertToQlUser(), nil
+}
+
+// ChangePassword is the resolver for the changePassword field.
+func (r *mutationResolver) ChangePassword(ctx context.Context, input entity.QlChangePasswordParam) (bool, error) {
+	if err := r.Uc.User.ChangePassword(ctx, input.ConvertToChangePasswordRequest()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteMe is the resolver for the deleteMe field.
+func (r *mutationResolver) DeleteMe(ctx context.Context) (bool, error) {
+	if err := r.Uc.User.SelfDelete(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RefreshToken is the resolver for the refreshToken field.
+func (r *mutationResolver) RefreshToken(ctx context.Context, input entity.QlRefreshTokenParam) (entity.QlRefreshTokenResponse, error) {
+	tokens, err := r.Uc.User.RefreshToken(ctx, entity.UserRefreshTokenParam(input))
+	if err != nil {
+		return entity.QlRefreshTokenResponse{}, err
+	}
+
+	return tokens.ConvertToQlRefreshTokenResponse(), nil
+}
+
+// Logout is the resolver for the logout field.
+func (r *mutationResolver) Logout(ctx context.Context, input entity.QlRefreshTokenParam) (bool, error) {
+	if err := r.Uc.User.Logout(ctx, entity.UserRefreshTokenParam(input)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LogoutAllSessions is the resolver for the logoutAllSessions field.
+func (r *mutationResolver) LogoutAllSessions(ctx context.Context) (bool, error) {
+	if err := r.Uc.User.LogoutAllSessions(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, input entity.QlCreateUserAdminParam) (entity.QlUser, error) {
+	user, err := r.Uc.User.Create(ctx, input.ConvertToCreateUserParam())
+	if err != nil {
+		return entity.QlUser{}, err
+	}
+
+	return user.ConvertToQlUser(), nil
+}
+
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, id int64, input entity.QlUpdateUserParam) (bool, error) {
+	selectParam := entity.UserParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.User.Update(ctx, input.ConvertToUpdateUserParam(), selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteUser is the resolver for the deleteUser field.
+func (r *mutationResolver) DeleteUser(ctx context.Context, id int64) (bool, error) {
+	selectParam := entity.UserParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.User.Delete(ctx, selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ActivateUser is the resolver for the activateUser field.
+func (r *mutationResolver) ActivateUser(ctx context.Context, id int64) (bool, error) {
+	selectParam := entity.UserParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.User.Activate(ctx, selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateRole is the resolver for the createRole field.
+func (r *mutationResolver) CreateRole(ctx context.Context, input entity.QlCreateRoleParam) (entity.QlRole, error) {
+	role, err := r.Uc.Role.Create(ctx, input.ConvertToCreateRoleParam())
+	if err != nil {
+		return entity.QlRole{}, err
+	}
+
+	return role.ConvertToQlRole(), nil
+}
+
+// UpdateRole is the resolver for the updateRole field.
+func (r *mutationResolver) UpdateRole(ctx context.Context, id int64, input entity.QlUpdateRoleParam) (bool, error) {
+	selectParam := entity.RoleParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.Role.Update(ctx, input.ConvertToUpdateRoleParam(), selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteRole is the resolver for the deleteRole field.
+func (r *mutationResolver) DeleteRole(ctx context.Context, id int64) (bool, error) {
+	selectParam := entity.RoleParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.Role.Delete(ctx, selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ActivateRole is the resolver for the activateRole field.
+func (r *mutationResolver) ActivateRole(ctx context.Context, id int64) (bool, error) {
+	selectParam := entity.RoleParam{
+		ID: null.Int64From(id),
+	}
+
+	if err := r.Uc.Role.Activate(ctx, selectParam); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 // Mutation returns MutationResolver implementation.
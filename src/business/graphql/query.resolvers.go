@@ -7,6 +7,9 @@ package graphql
 import (
 	"context"
 	"fmt"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/null"
 )
 
 // Foo is the resolver for the foo field.
@@ -14,6 +17,162 @@ func (r *queryResolver) Foo(ctx context.Context, bar string) (string, error) {
 	return fmt.Sprintf("Hello World %s", bar), nil
 }
 
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (entity.QlUser, error) {
+	user, err := r.Uc.User.GetSelfProfile(ctx)
+	if err != nil {
+		return entity.QlUser{}, err
+	}
+
+	return user.ConvertToQlUser(), nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, param *entity.QlUserParam, filter *entity.QlUserFilter, orderBy []entity.QlUserOrderBy) (entity.QlUserList, error) {
+	params := param.ConvertToUserParam()
+	if err := filter.ApplyToUserParam(&params); err != nil {
+		return entity.QlUserList{}, err
+	}
+
+	sortBy, err := entity.ConvertQlUserOrderBy(orderBy)
+	if err != nil {
+		return entity.QlUserList{}, err
+	}
+	params.SortBy = sortBy
+
+	users, pg, err := r.Uc.User.GetListAsAdmin(ctx, params)
+	if err != nil {
+		return entity.QlUserList{}, err
+	}
+
+	result := entity.QlUserList{
+		Users:      []entity.QlUser{},
+		Pagination: pg.ConvertToQlPagination(),
+	}
+	for _, user := range users {
+		result.Users = append(result.Users, user.ConvertToQlUser())
+	}
+
+	return result, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id int64) (entity.QlUser, error) {
+	user, err := r.Uc.User.GetAsAdmin(ctx, entity.UserParam{
+		ID: null.Int64From(id),
+	})
+	if err != nil {
+		return entity.QlUser{}, err
+	}
+
+	return user.ConvertToQlUser(), nil
+}
+
+// UsersConnection is the resolver for the usersConnection field.
+func (r *queryResolver) UsersConnection(ctx context.Context, first *int, after *string, param *entity.QlUserParam, filter *entity.QlUserFilter, orderBy *entity.QlUserOrderBy) (entity.QlUserConnection, error) {
+	params := param.ConvertToUserParam()
+	params.UseCursor = true
+	params.Limit = 0
+	if first != nil {
+		params.Limit = int64(*first)
+	}
+	if after != nil {
+		params.After = *after
+	}
+
+	if err := filter.ApplyToUserParam(&params); err != nil {
+		return entity.QlUserConnection{}, err
+	}
+
+	if orderBy != nil {
+		sortBy, err := entity.ConvertQlUserOrderBy([]entity.QlUserOrderBy{*orderBy})
+		if err != nil {
+			return entity.QlUserConnection{}, err
+		}
+		params.SortBy = sortBy
+	}
+
+	users, pg, err := r.Uc.User.GetListAsAdmin(ctx, params)
+	if err != nil {
+		return entity.QlUserConnection{}, err
+	}
+
+	return entity.ConvertToQlUserConnection(users, pg)
+}
+
+// Roles is the resolver for the roles field.
+func (r *queryResolver) Roles(ctx context.Context, param *entity.QlRoleParam, filter *entity.QlRoleFilter, orderBy []entity.QlRoleOrderBy) (entity.QlRoleList, error) {
+	params := param.ConvertToRoleParam()
+	if err := filter.ApplyToRoleParam(&params); err != nil {
+		return entity.QlRoleList{}, err
+	}
+
+	sortBy, err := entity.ConvertQlRoleOrderBy(orderBy)
+	if err != nil {
+		return entity.QlRoleList{}, err
+	}
+	params.SortBy = sortBy
+
+	roles, pg, err := r.Uc.Role.GetList(ctx, params)
+	if err != nil {
+		return entity.QlRoleList{}, err
+	}
+
+	result := entity.QlRoleList{
+		Roles:      []entity.QlRole{},
+		Pagination: pg.ConvertToQlPagination(),
+	}
+	for _, role := range roles {
+		result.Roles = append(result.Roles, role.ConvertToQlRole())
+	}
+
+	return result, nil
+}
+
+// Role is the resolver for the role field.
+func (r *queryResolver) Role(ctx context.Context, id int64) (entity.QlRole, error) {
+	role, err := r.Uc.Role.Get(ctx, entity.RoleParam{
+		ID: null.Int64From(id),
+	})
+	if err != nil {
+		return entity.QlRole{}, err
+	}
+
+	return role.ConvertToQlRole(), nil
+}
+
+// RolesConnection is the resolver for the rolesConnection field.
+func (r *queryResolver) RolesConnection(ctx context.Context, first *int, after *string, param *entity.QlRoleParam, filter *entity.QlRoleFilter, orderBy *entity.QlRoleOrderBy) (entity.QlRoleConnection, error) {
+	params := param.ConvertToRoleParam()
+	params.UseCursor = true
+	params.Limit = 0
+	if first != nil {
+		params.Limit = int64(*first)
+	}
+	if after != nil {
+		params.After = *after
+	}
+
+	if err := filter.ApplyToRoleParam(&params); err != nil {
+		return entity.QlRoleConnection{}, err
+	}
+
+	if orderBy != nil {
+		sortBy, err := entity.ConvertQlRoleOrderBy([]entity.QlRoleOrderBy{*orderBy})
+		if err != nil {
+			return entity.QlRoleConnection{}, err
+		}
+		params.SortBy = sortBy
+	}
+
+	roles, pg, err := r.Uc.Role.GetList(ctx, params)
+	if err != nil {
+		return entity.QlRoleConnection{}, err
+	}
+
+	return entity.ConvertToQlRoleConnection(roles, pg)
+}
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
@@ -2,6 +2,8 @@ package graphql
 
 import (
 	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
 	"github.com/adiatma85/own-go-sdk/log"
 )
 
@@ -10,6 +12,8 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	Uc  *usecase.Usecase
-	Log log.Interface
+	Uc      *usecase.Usecase
+	Log     log.Interface
+	PubSub  pubsub.Interface
+	Storage storage.Interface
 }
@@ -0,0 +1,240 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	mock_user "github.com/adiatma85/exp-golang-graphql/tests/mock/usecase/user"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestResolver(t *testing.T) (*Resolver, *mock_user.MockInterface) {
+	ctrl := gomock.NewController(t)
+	user := mock_user.NewMockInterface(ctrl)
+
+	return &Resolver{
+		Uc: &usecase.Usecase{User: user},
+	}, user
+}
+
+func Test_queryResolver_Me(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockFunc func(user *mock_user.MockInterface)
+		want     entity.QlUser
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name: "deactivated or anonymous caller",
+			mockFunc: func(user *mock_user.MockInterface) {
+				user.EXPECT().GetSelfProfile(gomock.Any()).
+					Return(entity.User{}, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "not found"))
+			},
+			wantCode: codes.CodeSQLRecordDoesNotExist,
+			wantErr:  true,
+		},
+		{
+			name: "own profile",
+			mockFunc: func(user *mock_user.MockInterface) {
+				user.EXPECT().GetSelfProfile(gomock.Any()).Return(entity.User{
+					ID:          42,
+					RoleId:      null.Int64From(3),
+					Email:       "jane@example.com",
+					Username:    "jane",
+					DisplayName: "Jane",
+					Status:      null.Int64From(entity.UserStatusActive),
+				}, nil)
+			},
+			want: entity.QlUser{
+				ID:          42,
+				Roleid:      3,
+				Email:       "jane@example.com",
+				Username:    "jane",
+				Displayname: "Jane",
+				Status:      null.Int64From(entity.UserStatusActive),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, user := newTestResolver(t)
+			tt.mockFunc(user)
+
+			got, err := r.Query().Me(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("queryResolver.Me() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_mutationResolver_UpdateMe(t *testing.T) {
+	username := "jane"
+
+	tests := []struct {
+		name     string
+		input    entity.QlUpdateSelfParam
+		mockFunc func(user *mock_user.MockInterface)
+		want     bool
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name:  "update failed",
+			input: entity.QlUpdateSelfParam{Username: &username},
+			mockFunc: func(user *mock_user.MockInterface) {
+				user.EXPECT().UpdateUserSelfProfile(gomock.Any(), entity.UpdateUserParam{Username: username}).
+					Return(errors.NewWithCode(codes.CodeSQLNoRowsAffected, "no rows affected"))
+			},
+			wantCode: codes.CodeSQLNoRowsAffected,
+			wantErr:  true,
+		},
+		{
+			name:  "only the given fields are updated",
+			input: entity.QlUpdateSelfParam{Username: &username},
+			mockFunc: func(user *mock_user.MockInterface) {
+				user.EXPECT().UpdateUserSelfProfile(gomock.Any(), entity.UpdateUserParam{Username: username}).Return(nil)
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, user := newTestResolver(t)
+			tt.mockFunc(user)
+
+			got, err := r.Mutation().UpdateMe(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mutationResolver.UpdateMe() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_entityResolver_FindManyQlUserByIDs(t *testing.T) {
+	reps := []*entity.QlUserByIDsInput{{ID: 3}, {ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name     string
+		mockFunc func(user *mock_user.MockInterface)
+		want     []*entity.QlUser
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name: "failed to read the users",
+			mockFunc: func(user *mock_user.MockInterface) {
+				user.EXPECT().GetListByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, errors.NewWithCode(codes.CodeSQLRead, "connection reset"))
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+		{
+			name: "users follow the order of the representations, missing or inactive ones are null",
+			mockFunc: func(user *mock_user.MockInterface) {
+				// user 2 is inactive or missing, the users are read in any order
+				user.EXPECT().GetListByIDs(gomock.Any(), []int64{3, 1, 2, 3}).
+					Return([]entity.User{{ID: 1, Email: "a@example.com"}, {ID: 3, Email: "c@example.com"}}, nil)
+			},
+			want: []*entity.QlUser{
+				{ID: 3, Email: "c@example.com"},
+				{ID: 1, Email: "a@example.com"},
+				nil,
+				{ID: 3, Email: "c@example.com"},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, user := newTestResolver(t)
+			tt.mockFunc(user)
+
+			got, err := r.Entity().FindManyQlUserByIDs(context.Background(), reps)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("entityResolver.FindManyQlUserByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+var testJwtAuth = jwtAuth.Init(jwtAuth.Config{Secret: "secret"})
+
+// newTestClient executes operations against the schema wired with the directives of the server
+func newTestClient(r *Resolver) *client.Client {
+	return client.New(handler.NewDefaultServer(NewExecutableSchema(Config{
+		Resolvers:  r,
+		Directives: NewDirectiveRoot(r.Uc, testJwtAuth),
+	})))
+}
+
+// asUser authenticates the operation as the given user
+func asUser(userID int64) client.Option {
+	return func(bd *client.Request) {
+		ctx := testJwtAuth.SetUserAuthInfo(bd.HTTP.Context(), jwtAuth.UserAuthParam{User: jwtAuth.User{ID: userID}})
+		bd.HTTP = bd.HTTP.WithContext(ctx)
+	}
+}
+
+func Test_entityResolver_directive(t *testing.T) {
+	r, user := newTestResolver(t)
+	user.EXPECT().GetSelfProfile(gomock.Any()).Return(entity.User{ID: 42}, nil)
+
+	// every field returning QlUser runs through the @entityResolver directive of the type
+	resp := struct {
+		Me struct {
+			ID string
+		}
+	}{}
+	assert.NoError(t, newTestClient(r).Post(`{ me { id } }`, &resp, asUser(42)))
+	assert.Equal(t, "42", resp.Me.ID)
+}
+
+func Test_entities(t *testing.T) {
+	r, user := newTestResolver(t)
+	user.EXPECT().GetListByIDs(gomock.Any(), []int64{2, 1}).Return([]entity.User{{ID: 1, Email: "a@example.com"}}, nil)
+
+	resp := struct {
+		Entities []*struct {
+			ID    string
+			Email string
+		} `json:"_entities"`
+	}{}
+	err := newTestClient(r).Post(`{ _entities(representations: [{__typename: "QlUser", id: "2"}, {__typename: "QlUser", id: "1"}]) { ... on QlUser { id email } } }`, &resp)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Entities, 2) {
+		assert.Nil(t, resp.Entities[0])
+		assert.Equal(t, "1", resp.Entities[1].ID)
+		assert.Equal(t, "a@example.com", resp.Entities[1].Email)
+	}
+}
@@ -0,0 +1,43 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+// subscribeUser streams the users published on the topic until the subscription context is done
+func (r *Resolver) subscribeUser(ctx context.Context, topic string) (<-chan entity.QlUser, error) {
+	if r.PubSub == nil {
+		return nil, errors.NewWithCode(codes.CodeNotImplemented, "subscriptions are not enabled")
+	}
+
+	payloads, err := r.PubSub.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan entity.QlUser, 1)
+	go func() {
+		defer close(results)
+
+		for payload := range payloads {
+			user := entity.User{}
+			if err := json.Unmarshal(payload, &user); err != nil {
+				r.Log.Error(ctx, errors.NewWithCode(codes.CodeUnmarshal, err.Error()))
+				continue
+			}
+
+			select {
+			case results <- user.ConvertToQlUser():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
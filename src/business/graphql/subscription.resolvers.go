@@ -0,0 +1,31 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+)
+
+// UserCreated is the resolver for the userCreated field.
+func (r *subscriptionResolver) UserCreated(ctx context.Context) (<-chan entity.QlUser, error) {
+	return r.subscribeUser(ctx, entity.TopicUserCreated)
+}
+
+// UserUpdated is the resolver for the userUpdated field.
+func (r *subscriptionResolver) UserUpdated(ctx context.Context) (<-chan entity.QlUser, error) {
+	return r.subscribeUser(ctx, entity.TopicUserUpdated)
+}
+
+// UserStatusChanged is the resolver for the userStatusChanged field.
+func (r *subscriptionResolver) UserStatusChanged(ctx context.Context) (<-chan entity.QlUser, error) {
+	return r.subscribeUser(ctx, entity.TopicUserStatusChanged)
+}
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,199 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	mock_role "github.com/adiatma85/exp-golang-graphql/tests/mock/usecase/role"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/null"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// notifyingPubSub tells when a subscriber is registered, publishing before that drops the message
+type notifyingPubSub struct {
+	pubsub.Interface
+	subscribed chan string
+}
+
+func (n *notifyingPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	payloads, err := n.Interface.Subscribe(ctx, topic)
+	n.subscribed <- topic
+	return payloads, err
+}
+
+func newTestSubscriptionResolver(t *testing.T) (*Resolver, *notifyingPubSub) {
+	ctrl := gomock.NewController(t)
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	ps := &notifyingPubSub{
+		Interface:  pubsub.Init(pubsub.InitParam{Conf: pubsub.Config{Type: pubsub.TypeMemory}, Log: logger}),
+		subscribed: make(chan string, 1),
+	}
+
+	return &Resolver{Log: logger, PubSub: ps}, ps
+}
+
+func publishUser(t *testing.T, ps pubsub.Interface, topic string, user entity.User) {
+	payload, err := json.Marshal(&user)
+	assert.NoError(t, err)
+	assert.NoError(t, ps.Publish(context.Background(), topic, payload))
+}
+
+// receive waits for the next user, ok is false once the channel is closed
+func receive(t *testing.T, users <-chan entity.QlUser) (entity.QlUser, bool) {
+	select {
+	case user, ok := <-users:
+		return user, ok
+	case <-time.After(time.Second):
+		t.Fatal("no user received")
+		return entity.QlUser{}, false
+	}
+}
+
+func Test_Resolver_subscribeUser(t *testing.T) {
+	t.Run("subscriptions are not enabled", func(t *testing.T) {
+		r := &Resolver{}
+
+		_, err := r.Subscription().UserCreated(context.Background())
+		assert.Equal(t, codes.CodeNotImplemented, errors.GetCode(err))
+	})
+
+	t.Run("only the users of the topic are delivered", func(t *testing.T) {
+		r, ps := newTestSubscriptionResolver(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		users, err := r.Subscription().UserCreated(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TopicUserCreated, <-ps.subscribed)
+
+		publishUser(t, ps, entity.TopicUserUpdated, entity.User{ID: 1})
+		// a malformed payload is logged and skipped, the subscription goes on
+		assert.NoError(t, ps.Publish(ctx, entity.TopicUserCreated, []byte("{")))
+		publishUser(t, ps, entity.TopicUserCreated, entity.User{ID: 42, Email: "jane@example.com", Status: null.Int64From(entity.UserStatusActive)})
+
+		user, ok := receive(t, users)
+		assert.True(t, ok)
+		assert.Equal(t, entity.QlUser{ID: 42, Email: "jane@example.com", Status: null.Int64From(entity.UserStatusActive)}, user)
+
+		select {
+		case user := <-users:
+			t.Errorf("unexpected user %v", user)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("the subscription ends with its context", func(t *testing.T) {
+		r, ps := newTestSubscriptionResolver(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		users, err := r.Subscription().UserStatusChanged(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TopicUserStatusChanged, <-ps.subscribed)
+
+		cancel()
+		_, ok := receive(t, users)
+		assert.False(t, ok, "the channel is closed once the context is done")
+	})
+}
+
+func Test_subscription_auth(t *testing.T) {
+	jwt := jwtAuth.Init(jwtAuth.Config{Secret: "secret", AccessTokenExpLimit: time.Hour})
+	token, err := jwt.CreateAccessToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+
+	query := `subscription { userCreated { id email } }`
+
+	tests := []struct {
+		name     string
+		initial  map[string]interface{}
+		authRole entity.Role
+		wantErr  bool
+	}{
+		{
+			name:    "anonymous connection",
+			wantErr: true,
+		},
+		{
+			name:     "user role",
+			initial:  map[string]interface{}{"Authorization": token},
+			authRole: entity.Role{ID: 3, Type: entity.RoleTypeUser},
+			wantErr:  true,
+		},
+		{
+			name:     "admin role",
+			initial:  map[string]interface{}{"Authorization": token},
+			authRole: entity.Role{ID: 2, Type: entity.RoleTypeAdmin},
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ps := newTestSubscriptionResolver(t)
+			role := mock_role.NewMockInterface(gomock.NewController(t))
+			if tt.authRole != (entity.Role{}) {
+				role.EXPECT().GetAuthRole(gomock.Any()).Return(tt.authRole, nil)
+			}
+			r.Uc = &usecase.Usecase{Role: role}
+
+			h := handler.New(NewExecutableSchema(Config{
+				Resolvers:  r,
+				Directives: NewDirectiveRoot(r.Uc, jwt),
+			}))
+			h.AddTransport(transport.Websocket{
+				KeepAlivePingInterval: time.Second,
+				// the connection is authenticated the way the handler does it
+				InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+					if initPayload.Authorization() == "" {
+						return ctx, nil, nil
+					}
+
+					user, err := jwt.ValidateAccessToken(initPayload.Authorization())
+					if err != nil {
+						return ctx, nil, err
+					}
+					return jwt.SetUserAuthInfo(ctx, jwtAuth.UserAuthParam{User: user}), nil, nil
+				},
+			})
+
+			sub := client.New(h).WebsocketWithPayload(query, tt.initial)
+			defer sub.Close()
+
+			if tt.wantErr {
+				resp := struct{ UserCreated *entity.QlUser }{}
+				assert.Error(t, sub.Next(&resp), "a denied subscription never subscribes")
+				assert.Nil(t, resp.UserCreated)
+				assert.Empty(t, ps.subscribed)
+				return
+			}
+
+			assert.Equal(t, entity.TopicUserCreated, <-ps.subscribed)
+			publishUser(t, ps, entity.TopicUserCreated, entity.User{ID: 7, Email: "john@example.com"})
+
+			resp := struct {
+				UserCreated struct {
+					ID    string
+					Email string
+				}
+			}{}
+			assert.NoError(t, sub.Next(&resp))
+			assert.Equal(t, "7", resp.UserCreated.ID)
+			assert.Equal(t, "john@example.com", resp.UserCreated.Email)
+		})
+	}
+}
@@ -4,12 +4,39 @@ package graphql
 // will be copied through when generating and any unknown code will be moved to the end.
 // Code generated by github.com/99designs/gqlgen version v0.17.49
 
-// Roleid is the resolver for the roleid field.
-// func (r *userResolver) Roleid(ctx context.Context, obj *entity.User) (int, error) {
-// 	panic(fmt.Errorf("not implemented: Roleid - roleid"))
-// }
+import (
+	"context"
 
-// User returns UserResolver implementation.
-// func (r *Resolver) User() UserResolver { return &userResolver{r} }
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/dataloader"
+)
 
-// type userResolver struct{ *Resolver }
+// AvatarURL is the resolver for the avatarUrl field.
+func (r *qlUserResolver) AvatarURL(ctx context.Context, obj *entity.QlUser) (*string, error) {
+	if !obj.Avatar.Valid || obj.Avatar.String == "" || r.Storage == nil {
+		return nil, nil
+	}
+
+	url := r.Storage.URL(obj.Avatar.String)
+	return &url, nil
+}
+
+// Role is the resolver for the role field.
+func (r *qlUserResolver) Role(ctx context.Context, obj *entity.QlUser) (*entity.QlRole, error) {
+	if obj.Roleid == 0 {
+		return nil, nil
+	}
+
+	role, err := dataloader.For(ctx).LoadRole(ctx, obj.Roleid)
+	if err != nil || role == nil {
+		return nil, err
+	}
+
+	result := role.ConvertToQlRole()
+	return &result, nil
+}
+
+// QlUser returns QlUserResolver implementation.
+func (r *Resolver) QlUser() QlUserResolver { return &qlUserResolver{r} }
+
+type qlUserResolver struct{ *Resolver }
@@ -0,0 +1,493 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	schema "github.com/adiatma85/exp-golang-graphql/src/business/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/cache"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/own-go-sdk/appcontext"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	directiveCacheControl     = "cacheControl"
+	directiveCacheInvalidates = "cacheInvalidates"
+	directiveAuthenticated    = "authenticated"
+	directiveHasRole          = "hasRole"
+	directiveMinRank          = "minRank"
+
+	defaultResponseCacheMaxAge = 5 * time.Minute
+	// invalidations are kept per type, the schema has far fewer types than this
+	cacheInvalidationSize = 1000
+)
+
+type cacheHeaderCtxKey struct{}
+type cachePolicyCtxKey struct{}
+
+// cachePolicy is the lowest max age and the narrowest scope of the fields resolved so far,
+// together with every type the fields belong to. A guarded field is never kept by clients,
+// they cannot check that the caller still has access to it.
+type cachePolicy struct {
+	mu      sync.Mutex
+	maxAge  int
+	private bool
+	guarded bool
+	types   map[string]struct{}
+}
+
+func newCachePolicy() *cachePolicy {
+	return &cachePolicy{
+		maxAge: -1,
+		types:  map[string]struct{}{},
+	}
+}
+
+func (p *cachePolicy) restrict(maxAge *int, private, guarded bool, typeNames ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if maxAge != nil && (p.maxAge < 0 || *maxAge < p.maxAge) {
+		p.maxAge = *maxAge
+	}
+	p.private = p.private || private
+	p.guarded = p.guarded || guarded
+	for _, name := range typeNames {
+		p.types[name] = struct{}{}
+	}
+}
+
+// cachedResponse is a stored response, it is stale once any of its types is invalidated after CachedAt
+type cachedResponse struct {
+	Response  json.RawMessage `json:"response"`
+	Private   bool            `json:"private"`
+	Guarded   bool            `json:"guarded"`
+	Types     []string        `json:"types"`
+	CachedAt  int64           `json:"cachedAt"`
+	ExpiresAt int64           `json:"expiresAt"`
+}
+
+// cacheControl computes the @cacheControl policy of every query, writes it as the Cache-Control
+// header of GET requests and, when enabled, serves queries from the response cache. Private
+// responses are cached per user, anonymous callers only share public ones. The guard directives
+// of a query run again before its response is served from the cache.
+type cacheControl struct {
+	conf   config.ResponseCacheConfig
+	schema *ast.Schema
+	guards schema.DirectiveRoot
+	// responses and invalidations are nil unless the response cache is enabled
+	responses     cache.Store
+	invalidations cache.Store
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = &cacheControl{}
+
+func (r *rest) cacheControl() *cacheControl {
+	c := &cacheControl{
+		conf:   r.conf.GraphQL.ResponseCache,
+		guards: schema.NewDirectiveRoot(r.uc, r.jwtAuth),
+	}
+
+	if c.conf.MaxAge <= 0 {
+		c.conf.MaxAge = defaultResponseCacheMaxAge
+	}
+
+	if c.conf.Enabled {
+		c.responses = cache.InitStore(cache.InitParam{
+			Type:   c.conf.Cache,
+			Size:   c.conf.Size,
+			Prefix: "rcache:response:",
+			Log:    r.log,
+			Redis:  r.redis,
+		})
+		c.invalidations = cache.InitStore(cache.InitParam{
+			Type:   c.conf.Cache,
+			Size:   cacheInvalidationSize,
+			Prefix: "rcache:type:",
+			Log:    r.log,
+			Redis:  r.redis,
+		})
+	}
+
+	return c
+}
+
+func (c *cacheControl) ExtensionName() string {
+	return "CacheControl"
+}
+
+func (c *cacheControl) Validate(schema graphql.ExecutableSchema) error {
+	c.schema = schema.Schema()
+	return nil
+}
+
+func (c *cacheControl) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation == nil {
+		return next(ctx)
+	}
+
+	switch rc.Operation.Operation {
+	case ast.Query:
+		return c.query(ctx, rc, next)
+	case ast.Mutation:
+		return c.mutation(ctx, next)
+	}
+
+	return next(ctx)
+}
+
+func (c *cacheControl) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	policy, ok := ctx.Value(cachePolicyCtxKey{}).(*cachePolicy)
+	if !ok {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Field.Definition == nil {
+		return next(ctx)
+	}
+
+	// a hint on the field wins over a hint on the type it returns
+	maxAge, private := cacheHint(fc.Field.Definition.Directives)
+
+	// the returned type is recorded even when no value of it is resolved, an empty list is stale too
+	typeNames := []string{}
+	if def := c.schema.Types[fc.Field.Definition.Type.Name()]; def != nil {
+		typeMaxAge, typePrivate := cacheHint(def.Directives)
+		if maxAge == nil {
+			maxAge = typeMaxAge
+		}
+		private = private || typePrivate
+
+		if def.Kind == ast.Object || def.Kind == ast.Interface || def.Kind == ast.Union {
+			typeNames = append(typeNames, def.Name)
+		}
+	}
+
+	if c.isRootType(fc.Object) {
+		// root fields without a hint are never cached, nested fields only narrow the policy of their root
+		if maxAge == nil {
+			maxAge = new(int)
+		}
+		// a mutation makes the types it lists in @cacheInvalidates stale too
+		typeNames = append(typeNames, cacheInvalidates(fc.Field.Definition.Directives)...)
+	} else {
+		typeNames = append(typeNames, fc.Object)
+	}
+
+	res, err := next(ctx)
+	// a failed field changed nothing to invalidate, a query with errors is never cached anyway
+	if err == nil {
+		policy.restrict(maxAge, private, len(guardDirectives(fc.Field.Definition.Directives)) > 0, typeNames...)
+	}
+
+	return res, err
+}
+
+func (c *cacheControl) query(ctx context.Context, rc *graphql.OperationContext, next graphql.ResponseHandler) *graphql.Response {
+	key := c.responseKey(rc)
+	userID := appcontext.GetUserId(ctx)
+
+	if c.responses != nil {
+		// a caller that lost its access since the response was stored gets the errors of the resolvers
+		if resp, entry, ok := c.lookup(ctx, key, userID); ok && c.authorize(ctx, rc) == nil {
+			setCacheControlHeader(ctx, int(time.Until(time.Unix(0, entry.ExpiresAt)).Seconds()), entry.Private, entry.Guarded)
+			return resp
+		}
+	}
+
+	policy := newCachePolicy()
+	resp := next(context.WithValue(ctx, cachePolicyCtxKey{}, policy))
+	if resp == nil {
+		return resp
+	}
+
+	maxAge, private := policy.maxAge, policy.private
+	if len(resp.Errors) > 0 {
+		maxAge = 0
+	}
+	setCacheControlHeader(ctx, maxAge, private, policy.guarded)
+
+	if c.responses == nil || maxAge <= 0 || (private && userID == 0) {
+		return resp
+	}
+
+	c.store(ctx, rc, key, userID, resp, policy)
+
+	return resp
+}
+
+func (c *cacheControl) mutation(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	policy := newCachePolicy()
+	resp := next(context.WithValue(ctx, cachePolicyCtxKey{}, policy))
+
+	if c.invalidations == nil {
+		return resp
+	}
+
+	// only the fields that resolved are recorded, with the types they return and the ones of @cacheInvalidates
+	invalidatedAt := strconv.FormatInt(time.Now().UnixNano(), 10)
+	for name := range policy.types {
+		c.invalidations.Set(ctx, name, invalidatedAt, c.conf.MaxAge)
+	}
+
+	return resp
+}
+
+// authorize runs the guard directives of every field the operation selects
+func (c *cacheControl) authorize(ctx context.Context, rc *graphql.OperationContext) error {
+	allow := func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	}
+
+	for _, d := range operationGuards(rc.Operation.SelectionSet) {
+		var err error
+		switch d.Name {
+		case directiveAuthenticated:
+			_, err = c.guards.Authenticated(ctx, nil, allow)
+		case directiveHasRole:
+			_, err = c.guards.HasRole(ctx, nil, allow, entity.QlRoleType(directiveArgument(d, "type")))
+		case directiveMinRank:
+			var rank int
+			if rank, err = strconv.Atoi(directiveArgument(d, "rank")); err == nil {
+				_, err = c.guards.MinRank(ctx, nil, allow, rank)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *cacheControl) lookup(ctx context.Context, key string, userID int) (*graphql.Response, cachedResponse, bool) {
+	keys := []string{key}
+	if userID != 0 {
+		keys = append(keys, privateResponseKey(key, userID))
+	}
+
+	for _, k := range keys {
+		raw, ok := c.responses.Get(ctx, k)
+		if !ok {
+			continue
+		}
+
+		entry := cachedResponse{}
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			continue
+		}
+
+		if time.Now().UnixNano() >= entry.ExpiresAt || c.isStale(ctx, entry) {
+			continue
+		}
+
+		resp := &graphql.Response{}
+		if err := json.Unmarshal(entry.Response, resp); err != nil {
+			continue
+		}
+
+		return resp, entry, true
+	}
+
+	return nil, cachedResponse{}, false
+}
+
+func (c *cacheControl) store(ctx context.Context, rc *graphql.OperationContext, key string, userID int, resp *graphql.Response, policy *cachePolicy) {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	ttl := time.Duration(policy.maxAge) * time.Second
+	if ttl > c.conf.MaxAge {
+		ttl = c.conf.MaxAge
+	}
+
+	entry := cachedResponse{
+		Response: raw,
+		Private:  policy.private,
+		Guarded:  policy.guarded,
+		Types:    make([]string, 0, len(policy.types)),
+		// a mutation that ran while the query did is newer than the response
+		CachedAt:  rc.Stats.OperationStart.UnixNano(),
+		ExpiresAt: time.Now().Add(ttl).UnixNano(),
+	}
+	for name := range policy.types {
+		entry.Types = append(entry.Types, name)
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if policy.private {
+		key = privateResponseKey(key, userID)
+	}
+	c.responses.Set(ctx, key, string(value), ttl)
+}
+
+func (c *cacheControl) isStale(ctx context.Context, entry cachedResponse) bool {
+	for _, name := range entry.Types {
+		value, ok := c.invalidations.Get(ctx, name)
+		if !ok {
+			continue
+		}
+
+		invalidatedAt, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || invalidatedAt >= entry.CachedAt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseKey hashes everything that selects the response, the caller is added for private responses
+func (c *cacheControl) responseKey(rc *graphql.OperationContext) string {
+	variables, _ := json.Marshal(rc.Variables)
+
+	h := sha256.New()
+	h.Write([]byte(rc.RawQuery))
+	h.Write([]byte{0})
+	h.Write([]byte(rc.OperationName))
+	h.Write([]byte{0})
+	h.Write(variables)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *cacheControl) isRootType(name string) bool {
+	for _, root := range []*ast.Definition{c.schema.Query, c.schema.Mutation, c.schema.Subscription} {
+		if root != nil && root.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func privateResponseKey(key string, userID int) string {
+	return fmt.Sprintf("%s:%d", key, userID)
+}
+
+func setCacheControlHeader(ctx context.Context, maxAge int, private, guarded bool) {
+	h, ok := ctx.Value(cacheHeaderCtxKey{}).(http.Header)
+	if !ok {
+		return
+	}
+
+	if maxAge <= 0 || guarded {
+		h.Set("Cache-Control", "no-store")
+		return
+	}
+
+	scope := "public"
+	if private {
+		scope = "private"
+	}
+	h.Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, maxAge))
+}
+
+func cacheHint(directives ast.DirectiveList) (*int, bool) {
+	d := directives.ForName(directiveCacheControl)
+	if d == nil {
+		return nil, false
+	}
+
+	var maxAge *int
+	if arg := d.Arguments.ForName("maxAge"); arg != nil {
+		if v, err := strconv.Atoi(arg.Value.Raw); err == nil {
+			maxAge = &v
+		}
+	}
+
+	private := false
+	if arg := d.Arguments.ForName("scope"); arg != nil {
+		private = arg.Value.Raw == string(entity.QlCacheControlScopePrivate)
+	}
+
+	return maxAge, private
+}
+
+func cacheInvalidates(directives ast.DirectiveList) []string {
+	d := directives.ForName(directiveCacheInvalidates)
+	if d == nil {
+		return nil
+	}
+
+	arg := d.Arguments.ForName("types")
+	if arg == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(arg.Value.Children))
+	for _, child := range arg.Value.Children {
+		names = append(names, child.Value.Raw)
+	}
+
+	return names
+}
+
+// operationGuards collects the guard directives of every field in the selections and their fragments
+func operationGuards(selections ast.SelectionSet) ast.DirectiveList {
+	guards := ast.DirectiveList{}
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Definition != nil {
+				guards = append(guards, guardDirectives(s.Definition.Directives)...)
+			}
+			guards = append(guards, operationGuards(s.SelectionSet)...)
+		case *ast.InlineFragment:
+			guards = append(guards, operationGuards(s.SelectionSet)...)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				guards = append(guards, operationGuards(s.Definition.SelectionSet)...)
+			}
+		}
+	}
+
+	return guards
+}
+
+func guardDirectives(directives ast.DirectiveList) ast.DirectiveList {
+	guards := ast.DirectiveList{}
+	for _, d := range directives {
+		switch d.Name {
+		case directiveAuthenticated, directiveHasRole, directiveMinRank:
+			guards = append(guards, d)
+		}
+	}
+
+	return guards
+}
+
+func directiveArgument(d *ast.Directive, name string) string {
+	arg := d.Arguments.ForName(name)
+	if arg == nil || arg.Value == nil {
+		return ""
+	}
+
+	return arg.Value.Raw
+}
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/cache"
+	mock_role "github.com/adiatma85/exp-golang-graphql/tests/mock/usecase/role"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/own-go-sdk/appcontext"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/mock/gomock"
+)
+
+var testResponseCacheConf = config.GinConfig{
+	GraphQL: config.GraphQLConfig{
+		ResponseCache: config.ResponseCacheConfig{Enabled: true, Cache: cache.TypeLRU, Size: 100},
+	},
+}
+
+// testCacheHint is the @cacheControl of a resolved field
+type testCacheHint struct {
+	maxAge  int
+	private bool
+}
+
+func newTestCacheControl(t *testing.T) *cacheControl {
+	ctrl := gomock.NewController(t)
+
+	r := &rest{conf: testResponseCacheConf, log: mock_log.NewMockInterface(ctrl)}
+	return r.cacheControl()
+}
+
+// newTestCacheServer serves the graphql handler with the response cache and the role usecase, requests
+// carrying a token are authenticated the way the rest middleware does it
+func newTestCacheServer(t *testing.T) (*rest, http.Handler, *mock_role.MockInterface) {
+	r, engine := newTestGraphqlServer(t, testResponseCacheConf)
+	role := mock_role.NewMockInterface(gomock.NewController(t))
+	r.uc.Role = role
+
+	return r, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if token := req.Header.Get("Authorization"); token != "" {
+			ctx, err := r.authenticate(req.Context(), token)
+			assert.NoError(t, err)
+			req = req.WithContext(ctx)
+		}
+		engine.ServeHTTP(w, req)
+	}), role
+}
+
+// getGraphql sends a query the way a cacheable client does
+func getGraphql(h http.Handler, query string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/query?query="+url.QueryEscape(query), nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func testAccessToken(t *testing.T, r *rest, userID int64) map[string]string {
+	token, err := r.jwtAuth.CreateAccessToken(jwtAuth.User{ID: userID})
+	assert.NoError(t, err)
+
+	return map[string]string{"Authorization": token}
+}
+
+// runQuery runs a query through the cache control, next resolves fields with the given hints and errors
+func runQuery(c *cacheControl, ctx context.Context, hints []testCacheHint, errs gqlerror.List, resolved *int) *graphql.Response {
+	rc := &graphql.OperationContext{
+		RawQuery:  "{ a b }",
+		Operation: &ast.OperationDefinition{Operation: ast.Query},
+		Stats:     graphql.Stats{OperationStart: time.Now()},
+	}
+
+	return c.InterceptResponse(graphql.WithOperationContext(ctx, rc), func(ctx context.Context) *graphql.Response {
+		*resolved++
+		policy := ctx.Value(cachePolicyCtxKey{}).(*cachePolicy)
+		for _, hint := range hints {
+			maxAge := hint.maxAge
+			policy.restrict(&maxAge, hint.private, false, "QlRole")
+		}
+		return &graphql.Response{Data: []byte(`{"a":"a","b":"b"}`), Errors: errs}
+	})
+}
+
+func Test_cacheControl_query(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		hints      []testCacheHint
+		errs       gqlerror.List
+		wantHeader string
+		wantStored bool
+	}{
+		{
+			name:       "the lowest max age and the private scope win",
+			userID:     1,
+			hints:      []testCacheHint{{maxAge: 60}, {maxAge: 30, private: true}, {maxAge: 300}},
+			wantHeader: "private, max-age=30",
+			wantStored: true,
+		},
+		{
+			name:       "public response of an anonymous caller",
+			hints:      []testCacheHint{{maxAge: 60}},
+			wantHeader: "public, max-age=60",
+			wantStored: true,
+		},
+		{
+			name:       "private response of an anonymous caller is not stored",
+			hints:      []testCacheHint{{maxAge: 60, private: true}},
+			wantHeader: "private, max-age=60",
+			wantStored: false,
+		},
+		{
+			name:       "errors force max age 0",
+			userID:     1,
+			hints:      []testCacheHint{{maxAge: 60}},
+			errs:       gqlerror.List{{Message: "failed"}},
+			wantHeader: "no-store",
+			wantStored: false,
+		},
+		{
+			name:       "no hint",
+			userID:     1,
+			wantHeader: "no-store",
+			wantStored: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCacheControl(t)
+			header := http.Header{}
+			ctx := context.WithValue(appcontext.SetUserId(context.Background(), tt.userID), cacheHeaderCtxKey{}, header)
+
+			resolved := 0
+			runQuery(c, ctx, tt.hints, tt.errs, &resolved)
+			assert.Equal(t, tt.wantHeader, header.Get("Cache-Control"))
+
+			resp := runQuery(c, ctx, tt.hints, tt.errs, &resolved)
+			if tt.wantStored {
+				assert.Equal(t, 1, resolved, "the second query is served from the cache")
+			} else {
+				assert.Equal(t, 2, resolved)
+			}
+			assert.JSONEq(t, `{"a":"a","b":"b"}`, string(resp.Data))
+		})
+	}
+}
+
+func Test_cacheControl_query_private(t *testing.T) {
+	c := newTestCacheControl(t)
+	hints := []testCacheHint{{maxAge: 60, private: true}}
+	asUser := func(userID int) context.Context {
+		return appcontext.SetUserId(context.Background(), userID)
+	}
+
+	resolved := 0
+	runQuery(c, asUser(1), hints, nil, &resolved)
+	runQuery(c, asUser(1), hints, nil, &resolved)
+	assert.Equal(t, 1, resolved, "the same user is served from the cache")
+
+	runQuery(c, asUser(2), hints, nil, &resolved)
+	assert.Equal(t, 2, resolved, "another user never gets the response of the first one")
+
+	runQuery(c, context.Background(), hints, nil, &resolved)
+	assert.Equal(t, 3, resolved, "an anonymous caller never gets a private response")
+}
+
+func Test_cacheControl_InterceptField(t *testing.T) {
+	c := newTestCacheControl(t)
+	c.schema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+		directive @cacheControl(maxAge: Int, scope: QlCacheControlScope) on FIELD_DEFINITION | OBJECT
+		directive @cacheInvalidates(types: [String!]!) on FIELD_DEFINITION
+		directive @hasRole(type: String!) on FIELD_DEFINITION
+		enum QlCacheControlScope { PUBLIC PRIVATE }
+
+		type Query {
+			thing: Thing
+			secret: String @hasRole(type: "ADMIN") @cacheControl(maxAge: 600)
+		}
+		type Mutation {
+			touch: Thing @cacheInvalidates(types: ["Other"])
+		}
+		type Thing @cacheControl(maxAge: 120) {
+			name: String @cacheControl(maxAge: 30, scope: PRIVATE)
+		}
+	`})
+
+	field := func(policy *cachePolicy, object, name string) context.Context {
+		ctx := context.WithValue(context.Background(), cachePolicyCtxKey{}, policy)
+		return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: name, Definition: c.schema.Types[object].Fields.ForName(name)}},
+		})
+	}
+	resolve := func(ctx context.Context) (interface{}, error) {
+		return "resolved", nil
+	}
+	fail := func(ctx context.Context) (interface{}, error) {
+		return nil, errors.NewWithCode(codes.CodeForbidden, "admin role is required")
+	}
+
+	// the hint of the type applies to the field returning it, the lowest max age and the private scope win
+	policy := newCachePolicy()
+	_, _ = c.InterceptField(field(policy, "Query", "thing"), resolve)
+	assert.Equal(t, 120, policy.maxAge)
+	_, _ = c.InterceptField(field(policy, "Thing", "name"), resolve)
+	assert.Equal(t, 30, policy.maxAge)
+	assert.True(t, policy.private)
+	assert.False(t, policy.guarded)
+	assert.Equal(t, map[string]struct{}{"Thing": {}}, policy.types)
+
+	policy = newCachePolicy()
+	_, _ = c.InterceptField(field(policy, "Query", "secret"), resolve)
+	assert.Equal(t, 600, policy.maxAge)
+	assert.True(t, policy.guarded, "clients never keep a guarded field")
+
+	// a mutation records the type it returns and the ones of @cacheInvalidates, only once it resolved
+	policy = newCachePolicy()
+	_, err := c.InterceptField(field(policy, "Mutation", "touch"), fail)
+	assert.Error(t, err)
+	assert.Empty(t, policy.types)
+
+	_, _ = c.InterceptField(field(policy, "Mutation", "touch"), resolve)
+	assert.Equal(t, map[string]struct{}{"Thing": {}, "Other": {}}, policy.types)
+}
+
+func Test_cacheControl_header(t *testing.T) {
+	r, h, role := newTestCacheServer(t)
+	role.EXPECT().GetAuthRole(gomock.Any()).Return(entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil).AnyTimes()
+	role.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Role{ID: 1, Name: "admin", Type: entity.RoleTypeAdmin}, nil).AnyTimes()
+
+	rec := getGraphql(h, `{ foo(bar: "x") }`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+
+	// served from the cache for the time it has left
+	rec = getGraphql(h, `{ foo(bar: "x") }`, nil)
+	assert.Regexp(t, `^public, max-age=(59|60)$`, rec.Header().Get("Cache-Control"))
+
+	// only GET responses are cacheable by clients and proxies
+	post, _ := postGraphql(t, h, `{"query": "{ foo(bar: \"x\") }"}`, nil)
+	assert.Empty(t, post.Header().Get("Cache-Control"))
+
+	// a guarded field is cached on the server only, where its guard runs again
+	admin := testAccessToken(t, r, 1)
+	rec = getGraphql(h, `{ foo(bar: "x") role(id: 1) { id } }`, admin)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	rec = getGraphql(h, `{ foo(bar: "x") role(id: 1) { id } }`, admin)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+}
+
+func Test_cacheControl_guards(t *testing.T) {
+	r, h, role := newTestCacheServer(t)
+	admin := testAccessToken(t, r, 1)
+	query := `{"query": "{ role(id: 1) { id name } }"}`
+
+	gomock.InOrder(
+		role.EXPECT().GetAuthRole(gomock.Any()).Return(entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil).Times(2),
+		// the admin role is revoked once the response is cached
+		role.EXPECT().GetAuthRole(gomock.Any()).Return(entity.Role{ID: 3, Type: entity.RoleTypeUser}, nil).MinTimes(1),
+	)
+	role.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Role{ID: 1, Name: "admin", Type: entity.RoleTypeAdmin}, nil).Times(1)
+
+	_, resp := postGraphql(t, h, query, admin)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "admin"}, resp.Data["role"])
+
+	// the guard runs again before the cached response is served
+	_, resp = postGraphql(t, h, query, admin)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "admin"}, resp.Data["role"])
+
+	_, resp = postGraphql(t, h, query, admin)
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, float64(codes.CodeForbidden), resp.Errors[0].Extensions["code"])
+	}
+	assert.Nil(t, resp.Data)
+
+	// an anonymous caller is denied without loading any role
+	_, resp = postGraphql(t, h, query, nil)
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, float64(codes.CodeAuthFailure), resp.Errors[0].Extensions["code"])
+	}
+}
+
+func Test_cacheControl_mutation(t *testing.T) {
+	r, h, role := newTestCacheServer(t)
+	admin := testAccessToken(t, r, 1)
+	query := `{"query": "{ role(id: 1) { id name } }"}`
+
+	// role management requires @minRank(rank: 100)
+	role.EXPECT().GetAuthRole(gomock.Any()).Return(entity.Role{ID: 2, Type: entity.RoleTypeAdmin, Rank: 100}, nil).AnyTimes()
+	role.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Role{ID: 1, Name: "admin", Type: entity.RoleTypeAdmin}, nil).Times(3)
+	gomock.InOrder(
+		role.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.NewWithCode(codes.CodeSQLTxExec, "connection reset")),
+		role.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+	)
+	role.EXPECT().Create(gomock.Any(), gomock.Any()).Return(entity.Role{ID: 4, Name: "editor", Type: entity.RoleTypeAdmin}, nil)
+
+	// resolved once, then served from the cache
+	postGraphql(t, h, query, admin)
+	postGraphql(t, h, query, admin)
+
+	// a failed mutation changed nothing, the response stays cached
+	_, resp := postGraphql(t, h, `{"query": "mutation { updateRole(id: 1, input: {name: \"editor\"}) }"}`, admin)
+	assert.NotEmpty(t, resp.Errors)
+	postGraphql(t, h, query, admin)
+
+	// @cacheInvalidates makes QlRole stale even though the mutation returns a Boolean
+	_, resp = postGraphql(t, h, `{"query": "mutation { updateRole(id: 1, input: {name: \"editor\"}) }"}`, admin)
+	assert.Empty(t, resp.Errors)
+	postGraphql(t, h, query, admin)
+	postGraphql(t, h, query, admin)
+
+	// the type a mutation returns is stale
+	_, resp = postGraphql(t, h, `{"query": "mutation { createRole(input: {name: \"editor\", type: \"admin\", rank: 10}) { id } }"}`, admin)
+	assert.Empty(t, resp.Errors)
+	postGraphql(t, h, query, admin)
+}
@@ -0,0 +1,515 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/dataloader"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/own-go-sdk/appcontext"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// graphqlErrorPresenter compiles coded errors the same way httpRespError does and exposes
+// the result in the error extensions. Messages of internal errors are replaced by the
+// localized body so SQL and driver details never reach the client.
+func (r *rest) graphqlErrorPresenter(ctx context.Context, e error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, e)
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["requestId"] = appcontext.GetRequestId(ctx)
+
+	// parsing and validation errors are produced by gqlgen itself and are safe to show
+	if gqlErr.Err == nil {
+		gqlErr.Extensions["httpStatus"] = http.StatusUnprocessableEntity
+		return gqlErr
+	}
+
+	err := findCodedError(gqlErr.Err)
+	httpStatus, displayError := errors.Compile(err, appcontext.GetAcceptLanguage(ctx))
+
+	if clientCode, ok := clientSQLCodes[displayError.Code]; ok {
+		// the message still carries the driver details, only the localized body is shown
+		displayError.Code = clientCode
+		gqlErr.Message = displayError.Body
+	} else if isInternalError(displayError.Code, httpStatus) {
+		r.log.Error(ctx, err)
+		gqlErr.Message = displayError.Body
+	}
+
+	gqlErr.Extensions["code"] = int(displayError.Code)
+	gqlErr.Extensions["httpStatus"] = httpStatus
+	gqlErr.Extensions["title"] = displayError.Title
+	gqlErr.Extensions["body"] = displayError.Body
+
+	return gqlErr
+}
+
+// graphqlRecover turns a resolver panic into an internal error, the presenter hides its details
+func (r *rest) graphqlRecover(ctx context.Context, p interface{}) error {
+	r.log.Error(ctx, fmt.Sprintf("graphql panic recovered: %v", p))
+	return errors.NewWithCode(codes.CodeInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// findCodedError unwraps gqlgen wrappers until an error carrying an app code is found
+func findCodedError(err error) error {
+	for e := err; e != nil; e = stderrors.Unwrap(e) {
+		if errors.GetCode(e) != codes.NoCode {
+			return e
+		}
+	}
+
+	return err
+}
+
+// clientSQLCodes are the sql errors caused by the input of the client, they are presented
+// with the matching client code instead of being logged as internal errors
+var clientSQLCodes = map[codes.Code]codes.Code{
+	codes.CodeSQLRecordDoesNotExist: codes.CodeNotFound,
+	codes.CodeSQLUniqueConstraint:   codes.CodeConflict,
+	codes.CodeSQLConflict:           codes.CodeConflict,
+}
+
+func isInternalError(code codes.Code, httpStatus int) bool {
+	if httpStatus >= http.StatusInternalServerError || code == codes.NoCode {
+		return true
+	}
+
+	// the other sql errors carry the driver message
+	return code >= codes.CodeSQL && code < codes.CodeClient
+}
+
+// operationLoaders attaches new loaders to every response the executor produces, so batching works
+// the same over every transport. A batch gets loaders per operation and a subscription per event,
+// a cached role never outlives the response it was loaded for.
+type operationLoaders struct {
+	uc *usecase.Usecase
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = operationLoaders{}
+
+func (operationLoaders) ExtensionName() string {
+	return "OperationLoaders"
+}
+
+func (operationLoaders) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (o operationLoaders) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	return next(dataloader.WithLoaders(ctx, dataloader.NewLoaders(ctx, o.uc)))
+}
+
+// queryLimit rejects operations that are too large, too deep, too aliased or too complex
+// before any resolver runs
+type queryLimit struct {
+	conf   config.GraphQLConfig
+	schema graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+	graphql.OperationContextMutator
+} = &queryLimit{}
+
+func (q *queryLimit) ExtensionName() string {
+	return "QueryLimit"
+}
+
+func (q *queryLimit) Validate(schema graphql.ExecutableSchema) error {
+	q.schema = schema
+	return nil
+}
+
+func (q *queryLimit) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	if q.conf.MaxDocumentSize > 0 && len(params.Query) > q.conf.MaxDocumentSize {
+		return operationError(codes.CodeBadRequest, "DOCUMENT_SIZE_LIMIT_EXCEEDED", "document size %d exceeds the limit of %d bytes", len(params.Query), q.conf.MaxDocumentSize)
+	}
+
+	return nil
+}
+
+func (q *queryLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	depth, aliases := measureSelectionSet(rc.Operation.SelectionSet, 1, map[string]bool{})
+
+	if q.conf.MaxDepth > 0 && depth > q.conf.MaxDepth {
+		return operationError(codes.CodeBadRequest, "DEPTH_LIMIT_EXCEEDED", "operation depth %d exceeds the limit of %d", depth, q.conf.MaxDepth)
+	}
+
+	if q.conf.MaxAliases > 0 && aliases > q.conf.MaxAliases {
+		return operationError(codes.CodeBadRequest, "ALIAS_LIMIT_EXCEEDED", "operation uses %d aliases, the limit is %d", aliases, q.conf.MaxAliases)
+	}
+
+	if q.conf.MaxComplexity > 0 {
+		if c := complexity.Calculate(q.schema, rc.Operation, rc.Variables); c > q.conf.MaxComplexity {
+			return operationError(codes.CodeBadRequest, "COMPLEXITY_LIMIT_EXCEEDED", "operation complexity %d exceeds the limit of %d", c, q.conf.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+// measureSelectionSet returns the deepest field level and the amount of aliased fields.
+// Introspection fields are skipped so the playground schema query is never rejected.
+func measureSelectionSet(selectionSet ast.SelectionSet, level int, visited map[string]bool) (int, int) {
+	depth, aliases := 0, 0
+
+	for _, selection := range selectionSet {
+		var childDepth, childAliases int
+
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+
+			if s.Alias != "" && s.Alias != s.Name {
+				aliases++
+			}
+
+			childDepth, childAliases = level, 0
+			if len(s.SelectionSet) > 0 {
+				childDepth, childAliases = measureSelectionSet(s.SelectionSet, level+1, visited)
+			}
+		case *ast.InlineFragment:
+			childDepth, childAliases = measureSelectionSet(s.SelectionSet, level, visited)
+		case *ast.FragmentSpread:
+			// fragment cycles are rejected by validation, visited only guards repeated spreads on the same path
+			if s.Definition == nil || visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			childDepth, childAliases = measureSelectionSet(s.Definition.SelectionSet, level, visited)
+			delete(visited, s.Name)
+		}
+
+		if childDepth > depth {
+			depth = childDepth
+		}
+		aliases += childAliases
+	}
+
+	return depth, aliases
+}
+
+func operationError(code codes.Code, reason string, msg string, val ...interface{}) *gqlerror.Error {
+	err := errors.NewWithCode(code, msg, val...)
+
+	return &gqlerror.Error{
+		Err:     err,
+		Message: fmt.Sprintf(msg, val...),
+		Extensions: map[string]interface{}{
+			"reason": reason,
+		},
+	}
+}
+
+// trustedDocuments only lets through operations registered in the manifest at build time.
+// The manifest maps the sha256 hash of each document to the document itself.
+type trustedDocuments struct {
+	documents map[string]string
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = &trustedDocuments{}
+
+func (r *rest) loadTrustedDocuments(path string) (*trustedDocuments, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	documents := map[string]string{}
+	if err := r.json.Unmarshal(raw, &documents); err != nil {
+		return nil, errors.NewWithCode(codes.CodeUnmarshal, err.Error())
+	}
+
+	for hash, document := range documents {
+		if hashDocument(document) != strings.ToLower(hash) {
+			return nil, errors.NewWithCode(codes.CodeInvalidValue, "trusted document %s does not match its hash", hash)
+		}
+	}
+
+	return &trustedDocuments{documents: documents}, nil
+}
+
+func (t *trustedDocuments) ExtensionName() string {
+	return "TrustedDocuments"
+}
+
+func (t *trustedDocuments) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (t *trustedDocuments) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	// the gateway plans its operations at runtime, they can never be in the manifest
+	if isGateway(ctx) {
+		return nil
+	}
+
+	hash := persistedQueryHash(params.Extensions)
+	if hash == "" {
+		hash = hashDocument(params.Query)
+	}
+
+	document, ok := t.documents[strings.ToLower(hash)]
+	if !ok || (params.Query != "" && params.Query != document) {
+		return operationError(codes.CodeForbidden, "UNTRUSTED_DOCUMENT", "operation is not a trusted document")
+	}
+
+	params.Query = document
+	return nil
+}
+
+func persistedQueryHash(extensions map[string]interface{}) string {
+	persistedQuery, ok := extensions["persistedQuery"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
+
+func hashDocument(document string) string {
+	sum := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(sum[:])
+}
+
+// federatedService only serves _service and _entities to the authenticated gateway, entities expose
+// fields no anonymous caller may read. The gateway also reads _service { sdl } when introspection is
+// disabled, but only in operations selecting nothing else, __schema stays disabled.
+type federatedService struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = federatedService{}
+
+func (federatedService) ExtensionName() string {
+	return "FederatedService"
+}
+
+func (federatedService) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (federatedService) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if rc.Operation == nil || rc.Operation.Operation != ast.Query {
+		return nil
+	}
+
+	federated, serviceOnly := false, true
+	for _, field := range graphql.CollectFields(rc, rc.Operation.SelectionSet, nil) {
+		switch field.Name {
+		case "_service", "_entities":
+			federated = true
+			serviceOnly = serviceOnly && field.Name == "_service"
+		case "__typename":
+		default:
+			serviceOnly = false
+		}
+	}
+
+	if !federated {
+		return nil
+	}
+
+	if !isGateway(ctx) {
+		return operationError(codes.CodeForbidden, "GATEWAY_REQUIRED", "federation fields are only served to the gateway")
+	}
+
+	if serviceOnly {
+		rc.DisableIntrospection = false
+	}
+
+	return nil
+}
+
+// introspectionDisabled rejects __schema and __type before execution while introspection is off,
+// gqlgen would fail the field with an uncoded error that is presented as an internal one
+type introspectionDisabled struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = introspectionDisabled{}
+
+func (introspectionDisabled) ExtensionName() string {
+	return "IntrospectionDisabled"
+}
+
+func (introspectionDisabled) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (introspectionDisabled) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if !rc.DisableIntrospection || rc.Operation == nil || rc.Operation.Operation != ast.Query {
+		return nil
+	}
+
+	for _, field := range graphql.CollectFields(rc, rc.Operation.SelectionSet, nil) {
+		if field.Name == "__schema" || field.Name == "__type" {
+			return operationError(codes.CodeForbidden, "INTROSPECTION_DISABLED", "introspection is disabled")
+		}
+	}
+
+	return nil
+}
+
+// isGateway reports whether the request carries the federation gateway secret
+func isGateway(ctx context.Context) bool {
+	gateway, _ := ctx.Value(gatewayCtxKey{}).(bool)
+	return gateway
+}
+
+const (
+	defaultBatchMaxOperations = 10
+	// a batch is a handful of documents and their variables, far below this
+	batchMaxBodySize = 1 << 20
+)
+
+// batchPOST serves a json array of operations and answers with an array of responses in the same order.
+// Every operation goes through the executor on its own, so limits, persisted queries and errors stay
+// isolated per operation. Operations run concurrently and must not depend on each other.
+type batchPOST struct {
+	conf config.BatchConfig
+}
+
+var _ graphql.Transport = batchPOST{}
+
+func (b batchPOST) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return false
+	}
+
+	// only the leading bytes are peeked, the buffered body is left whole for the transport that reads it
+	body := bufio.NewReader(r.Body)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{body, r.Body}
+
+	for n := 1; ; n++ {
+		peeked, err := body.Peek(n)
+		if err != nil {
+			return false
+		}
+
+		switch peeked[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		return peeked[n-1] == '['
+	}
+}
+
+func (b batchPOST) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "application/json")
+
+	maxOperations := b.conf.MaxOperations
+	if maxOperations < 1 {
+		maxOperations = defaultBatchMaxOperations
+	}
+
+	start := graphql.Now()
+	batch := []*graphql.RawParams{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, batchMaxBodySize)).Decode(&batch); err != nil {
+		maxBytesErr := &http.MaxBytesError{}
+		if stderrors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			writeGraphqlResponse(w, exec.DispatchError(ctx, gqlerror.List{operationError(codes.CodeBadRequest, "BATCH_LIMIT", "batch exceeds the limit of %d bytes", batchMaxBodySize)}))
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		writeGraphqlResponse(w, exec.DispatchError(ctx, gqlerror.List{operationError(codes.CodeBadRequest, "INVALID_BATCH", "batch could not be decoded")}))
+		return
+	}
+
+	if len(batch) == 0 || len(batch) > maxOperations {
+		w.WriteHeader(http.StatusBadRequest)
+		writeGraphqlResponse(w, exec.DispatchError(ctx, gqlerror.List{operationError(codes.CodeBadRequest, "BATCH_LIMIT", "batch must have between 1 and %d operations", maxOperations)}))
+		return
+	}
+
+	readTime := graphql.TraceTiming{Start: start, End: graphql.Now()}
+
+	concurrency := b.conf.MaxConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	responses := make([]*graphql.Response, len(batch))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, params := range batch {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if params == nil {
+				responses[i] = exec.DispatchError(ctx, gqlerror.List{operationError(codes.CodeBadRequest, "INVALID_BATCH", "operation %d is not an object", i)})
+				return
+			}
+
+			params.Headers = r.Header
+			params.ReadTime = readTime
+			responses[i] = executeOperation(ctx, exec, params)
+		}()
+	}
+	wg.Wait()
+
+	writeGraphqlResponse(w, responses)
+}
+
+func executeOperation(ctx context.Context, exec graphql.GraphExecutor, params *graphql.RawParams) *graphql.Response {
+	rc, opErr := exec.CreateOperationContext(ctx, params)
+	if opErr != nil {
+		return exec.DispatchError(graphql.WithOperationContext(ctx, rc), opErr)
+	}
+
+	responses, ctx := exec.DispatchOperation(ctx, rc)
+	return responses(ctx)
+}
+
+func writeGraphqlResponse(w io.Writer, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,842 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	schema "github.com/adiatma85/exp-golang-graphql/src/business/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/parser"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/mock/gomock"
+)
+
+// inFlight records the most operations that were executing at the same time
+type inFlight struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (i *inFlight) ExtensionName() string {
+	return "InFlight"
+}
+
+func (i *inFlight) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (i *inFlight) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	i.mu.Lock()
+	i.current++
+	if i.current > i.max {
+		i.max = i.current
+	}
+	i.mu.Unlock()
+
+	defer func() {
+		i.mu.Lock()
+		i.current--
+		i.mu.Unlock()
+	}()
+
+	// long enough for the other operations of the batch to start
+	time.Sleep(10 * time.Millisecond)
+
+	return next(ctx)
+}
+
+// testGraphqlResponse is a single graphql response as the client receives it
+type testGraphqlResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// reason is the rejection reason of the first error, empty when the operation was not rejected
+func (g testGraphqlResponse) reason() string {
+	if len(g.Errors) == 0 {
+		return ""
+	}
+
+	reason, _ := g.Errors[0].Extensions["reason"].(string)
+	return reason
+}
+
+// newTestGraphqlServer serves the graphql handler wired the same way Register does, without any usecase
+func newTestGraphqlServer(t *testing.T, conf config.GinConfig) (*rest, http.Handler) {
+	ctrl := gomock.NewController(t)
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	jwt := jwtAuth.Init(jwtAuth.Config{Secret: "secret", AccessTokenExpLimit: time.Hour})
+	uc := &usecase.Usecase{}
+	r := &rest{
+		conf:    conf,
+		log:     logger,
+		json:    parser.InitParser(logger, parser.Options{}).JSONParser(),
+		uc:      uc,
+		jwtAuth: jwt,
+		graphql: schema.NewExecutableSchema(schema.Config{
+			Resolvers:  &schema.Resolver{Uc: uc, Log: logger},
+			Directives: schema.NewDirectiveRoot(uc, jwt),
+			Complexity: schema.NewComplexityRoot(),
+		}),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Any("/query", r.graphqlHandler())
+
+	return r, engine
+}
+
+// postGraphql sends a single operation and decodes its response
+func postGraphql(t *testing.T, h http.Handler, body string, headers map[string]string) (*httptest.ResponseRecorder, testGraphqlResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	resp := testGraphqlResponse{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+
+	return rec, resp
+}
+
+func Test_queryLimit(t *testing.T) {
+	conf := config.GinConfig{
+		GraphQL: config.GraphQLConfig{
+			MaxDepth:        3,
+			MaxAliases:      2,
+			MaxComplexity:   50,
+			MaxDocumentSize: 300,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantReason string
+	}{
+		{
+			name:       "within every limit",
+			query:      `{ foo(bar: \"x\") }`,
+			wantReason: "",
+		},
+		{
+			name:       "too deep",
+			query:      `{ users { users { role { id } } } }`,
+			wantReason: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:       "too deep through a fragment",
+			query:      `query { users { ...U } } fragment U on QlUserList { users { role { id } } }`,
+			wantReason: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:       "introspection does not count to the depth",
+			query:      `{ __schema { types { fields { type { name } } } } }`,
+			wantReason: "",
+		},
+		{
+			name:       "too many aliases",
+			query:      `{ a: foo(bar: \"1\") b: foo(bar: \"2\") c: foo(bar: \"3\") }`,
+			wantReason: "ALIAS_LIMIT_EXCEEDED",
+		},
+		{
+			name:       "too complex",
+			query:      `{ users(param: {limit: 100}) { users { id } } }`,
+			wantReason: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+		{
+			name:       "document too large",
+			query:      `{ foo(bar: \"x\") ` + strings.Repeat(" ", 300) + `}`,
+			wantReason: "DOCUMENT_SIZE_LIMIT_EXCEEDED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, h := newTestGraphqlServer(t, conf)
+
+			_, resp := postGraphql(t, h, `{"query": "`+tt.query+`"}`, nil)
+
+			assert.Equal(t, tt.wantReason, resp.reason(), resp.Errors)
+			if tt.wantReason == "" {
+				assert.Empty(t, resp.Errors)
+				return
+			}
+			assert.Nil(t, resp.Data, "a rejected operation never runs")
+			assert.Equal(t, float64(codes.CodeBadRequest), resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func Test_automaticPersistedQuery(t *testing.T) {
+	_, h := newTestGraphqlServer(t, config.GinConfig{})
+
+	query := `{ foo(bar: "persisted") }`
+	hashOnly := `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "` + hashDocument(query) + `"}}}`
+
+	// an unknown hash asks the client to send the document
+	_, resp := postGraphql(t, h, hashOnly, nil)
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, "PersistedQueryNotFound", resp.Errors[0].Message)
+	}
+
+	// a document that does not match its hash is never registered
+	_, resp = postGraphql(t, h, `{"query": "{ foo(bar: \"other\") }", "extensions": {"persistedQuery": {"version": 1, "sha256Hash": "`+hashDocument(query)+`"}}}`, nil)
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, "provided APQ hash does not match query", resp.Errors[0].Message)
+	}
+
+	// the document is registered with its hash, the hash alone is enough afterwards
+	_, resp = postGraphql(t, h, `{"query": "{ foo(bar: \"persisted\") }", "extensions": {"persistedQuery": {"version": 1, "sha256Hash": "`+hashDocument(query)+`"}}}`, nil)
+	assert.Empty(t, resp.Errors)
+
+	_, resp = postGraphql(t, h, hashOnly, nil)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, "Hello World persisted", resp.Data["foo"])
+}
+
+func Test_trustedDocuments(t *testing.T) {
+	trusted := `{ foo(bar: "trusted") }`
+	manifest := filepath.Join(t.TempDir(), "manifest.json")
+	raw, _ := json.Marshal(map[string]string{hashDocument(trusted): trusted})
+	assert.NoError(t, os.WriteFile(manifest, raw, 0o600))
+
+	_, h := newTestGraphqlServer(t, config.GinConfig{
+		Mode: gin.ReleaseMode,
+		GraphQL: config.GraphQLConfig{
+			TrustedDocuments: config.TrustedDocumentsConfig{Enabled: true, ManifestPath: manifest},
+			Federation:       config.FederationConfig{Enabled: true, Secret: "gateway-secret"},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		headers    map[string]string
+		wantReason string
+	}{
+		{
+			name:       "trusted document",
+			body:       `{"query": "{ foo(bar: \"trusted\") }"}`,
+			wantReason: "",
+		},
+		{
+			name:       "trusted document by its hash",
+			body:       `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "` + hashDocument(trusted) + `"}}}`,
+			wantReason: "",
+		},
+		{
+			name:       "untrusted document",
+			body:       `{"query": "{ foo(bar: \"untrusted\") }"}`,
+			wantReason: "UNTRUSTED_DOCUMENT",
+		},
+		{
+			name:       "untrusted document under the hash of a trusted one",
+			body:       `{"query": "{ foo(bar: \"untrusted\") }", "extensions": {"persistedQuery": {"version": 1, "sha256Hash": "` + hashDocument(trusted) + `"}}}`,
+			wantReason: "UNTRUSTED_DOCUMENT",
+		},
+		{
+			name:       "gateway operations are planned at runtime and bypass the manifest",
+			body:       `{"query": "{ foo(bar: \"untrusted\") }"}`,
+			headers:    map[string]string{headerGatewaySecret: "gateway-secret"},
+			wantReason: "",
+		},
+		{
+			name:       "a wrong gateway secret does not bypass the manifest",
+			body:       `{"query": "{ foo(bar: \"untrusted\") }"}`,
+			headers:    map[string]string{headerGatewaySecret: "guess"},
+			wantReason: "UNTRUSTED_DOCUMENT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp := postGraphql(t, h, tt.body, tt.headers)
+
+			assert.Equal(t, tt.wantReason, resp.reason(), resp.Errors)
+			if tt.wantReason == "" {
+				assert.Empty(t, resp.Errors)
+				return
+			}
+			assert.Nil(t, resp.Data)
+			assert.Equal(t, float64(codes.CodeForbidden), resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func Test_rest_loadTrustedDocuments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	r := &rest{json: parser.InitParser(mock_log.NewMockInterface(ctrl), parser.Options{}).JSONParser()}
+
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "manifest.json")
+	assert.NoError(t, os.WriteFile(manifest, []byte(`{"`+hashDocument("{ me { id } }")+`": "{ foo(bar: \"swapped\") }"}`), 0o600))
+
+	_, err := r.loadTrustedDocuments(manifest)
+	assert.Equal(t, codes.CodeInvalidValue, errors.GetCode(err), "a document that does not match its hash is refused")
+
+	_, err = r.loadTrustedDocuments(filepath.Join(dir, "missing.json"))
+	assert.Equal(t, codes.CodeFilePathOpenFailed, errors.GetCode(err))
+}
+
+func Test_introspection(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    config.GinConfig
+		wantErr bool
+	}{
+		{
+			name:    "always on while developing",
+			conf:    config.GinConfig{Mode: gin.DebugMode},
+			wantErr: false,
+		},
+		{
+			name:    "off in release mode",
+			conf:    config.GinConfig{Mode: gin.ReleaseMode},
+			wantErr: true,
+		},
+		{
+			name:    "switched on in release mode",
+			conf:    config.GinConfig{Mode: gin.ReleaseMode, GraphQL: config.GraphQLConfig{Introspection: true}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, h := newTestGraphqlServer(t, tt.conf)
+
+			_, resp := postGraphql(t, h, `{"query": "{ __schema { queryType { name } } }"}`, nil)
+			if !tt.wantErr {
+				assert.Empty(t, resp.Errors)
+				assert.NotNil(t, resp.Data["__schema"])
+				return
+			}
+			assert.Equal(t, "INTROSPECTION_DISABLED", resp.reason())
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, "introspection is disabled", resp.Errors[0].Message)
+				assert.Equal(t, float64(codes.CodeForbidden), resp.Errors[0].Extensions["code"])
+			}
+			assert.Nil(t, resp.Data)
+
+			_, resp = postGraphql(t, h, `{"query": "{ __type(name: \"Query\") { name } }"}`, nil)
+			assert.Equal(t, "INTROSPECTION_DISABLED", resp.reason())
+
+			// the rest of the schema is still served
+			_, resp = postGraphql(t, h, `{"query": "{ __typename foo(bar: \"x\") }"}`, nil)
+			assert.Empty(t, resp.Errors)
+		})
+	}
+}
+
+func Test_federatedService(t *testing.T) {
+	// introspection is off in release mode, the gateway still reads the sdl
+	_, h := newTestGraphqlServer(t, config.GinConfig{
+		Mode: gin.ReleaseMode,
+		GraphQL: config.GraphQLConfig{
+			Federation: config.FederationConfig{Enabled: true, Secret: "gateway-secret"},
+		},
+	})
+
+	gateway := map[string]string{headerGatewaySecret: "gateway-secret"}
+	entities := `{"query": "{ _entities(representations: [{__typename: \"QlUser\", id: \"1\"}]) { __typename } }"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		headers    map[string]string
+		wantReason string
+	}{
+		{
+			name:       "sdl without a secret",
+			body:       `{"query": "{ _service { sdl } }"}`,
+			wantReason: "GATEWAY_REQUIRED",
+		},
+		{
+			name:       "sdl with a wrong secret",
+			body:       `{"query": "{ _service { sdl } }"}`,
+			headers:    map[string]string{headerGatewaySecret: "guess"},
+			wantReason: "GATEWAY_REQUIRED",
+		},
+		{
+			name:       "entities without a secret",
+			body:       entities,
+			wantReason: "GATEWAY_REQUIRED",
+		},
+		{
+			name:       "entities with a wrong secret",
+			body:       entities,
+			headers:    map[string]string{headerGatewaySecret: "gateway-secret-but-longer"},
+			wantReason: "GATEWAY_REQUIRED",
+		},
+		{
+			name:       "sdl for the gateway",
+			body:       `{"query": "{ _service { sdl } }"}`,
+			headers:    gateway,
+			wantReason: "",
+		},
+		{
+			name:       "the gateway never opens the rest of introspection",
+			body:       `{"query": "{ _service { sdl } __schema { queryType { name } } }"}`,
+			headers:    gateway,
+			wantReason: "INTROSPECTION_DISABLED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp := postGraphql(t, h, tt.body, tt.headers)
+
+			assert.Equal(t, tt.wantReason, resp.reason(), resp.Errors)
+			if tt.wantReason == "" {
+				assert.Empty(t, resp.Errors)
+				service, _ := resp.Data["_service"].(map[string]interface{})
+				assert.Contains(t, service["sdl"], "type QlUser")
+				return
+			}
+			assert.Nil(t, resp.Data)
+			assert.Equal(t, float64(codes.CodeForbidden), resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func Test_rest_isGatewayRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		conf   config.FederationConfig
+		secret string
+		want   bool
+	}{
+		{name: "matching secret", conf: config.FederationConfig{Enabled: true, Secret: "gateway-secret"}, secret: "gateway-secret", want: true},
+		{name: "missing secret", conf: config.FederationConfig{Enabled: true, Secret: "gateway-secret"}, secret: "", want: false},
+		{name: "wrong secret", conf: config.FederationConfig{Enabled: true, Secret: "gateway-secret"}, secret: "gateway", want: false},
+		{name: "federation disabled", conf: config.FederationConfig{Enabled: false, Secret: "gateway-secret"}, secret: "gateway-secret", want: false},
+		{name: "no secret configured is never trusted", conf: config.FederationConfig{Enabled: true}, secret: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &rest{}
+			r.conf.GraphQL.Federation = tt.conf
+
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			if tt.secret != "" {
+				req.Header.Set(headerGatewaySecret, tt.secret)
+			}
+
+			assert.Equal(t, tt.want, r.isGatewayRequest(req))
+		})
+	}
+}
+
+func Test_batchPOST(t *testing.T) {
+	type response struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []struct {
+			Message    string                 `json:"message"`
+			Extensions map[string]interface{} `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	newServer := func(conf config.GraphQLConfig, tracker *inFlight) http.Handler {
+		h := handler.New(schema.NewExecutableSchema(schema.Config{
+			Resolvers:  &schema.Resolver{},
+			Directives: schema.NewDirectiveRoot(nil, nil),
+			Complexity: schema.NewComplexityRoot(),
+		}))
+		h.AddTransport(batchPOST{conf: conf.Batch})
+		h.Use(&queryLimit{conf: conf})
+		if tracker != nil {
+			h.Use(tracker)
+		}
+
+		return h
+	}
+
+	conf := config.GraphQLConfig{
+		MaxComplexity:   50,
+		MaxDocumentSize: 200,
+		Batch: config.BatchConfig{
+			Enabled:        true,
+			MaxOperations:  4,
+			MaxConcurrency: 2,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		conf           config.GraphQLConfig
+		body           string
+		wantStatus     int
+		wantResponses  int
+		wantReasons    []string
+		wantTypenames  []interface{}
+		wantConcurrent int
+	}{
+		{
+			name: "every operation is answered in order on its own",
+			conf: conf,
+			body: `[
+				{"query": "{ __typename }"},
+				{"query": "{ unknownField }"},
+				{"query": "{ users(param: {limit: 100}) { users { id } } }"},
+				{"query": "query Typename { __typename }", "operationName": "Typename"}
+			]`,
+			wantStatus:    http.StatusOK,
+			wantResponses: 4,
+			wantReasons:   []string{"", "", "COMPLEXITY_LIMIT_EXCEEDED", ""},
+			wantTypenames: []interface{}{"Query", nil, nil, "Query"},
+		},
+		{
+			name: "document size is limited per operation",
+			conf: conf,
+			body: `[
+				{"query": "{ __typename }"},
+				{"query": "{ __typename ` + strings.Repeat(" ", 200) + `}"}
+			]`,
+			wantStatus:    http.StatusOK,
+			wantResponses: 2,
+			wantReasons:   []string{"", "DOCUMENT_SIZE_LIMIT_EXCEEDED"},
+			wantTypenames: []interface{}{"Query", nil},
+		},
+		{
+			name:          "too many operations",
+			conf:          conf,
+			body:          `[` + strings.TrimSuffix(strings.Repeat(`{"query": "{ __typename }"},`, 5), ",") + `]`,
+			wantStatus:    http.StatusBadRequest,
+			wantResponses: 1,
+			wantReasons:   []string{"BATCH_LIMIT"},
+		},
+		{
+			name:          "empty batch",
+			conf:          conf,
+			body:          ` []`,
+			wantStatus:    http.StatusBadRequest,
+			wantResponses: 1,
+			wantReasons:   []string{"BATCH_LIMIT"},
+		},
+		{
+			name:          "batch larger than the body limit",
+			conf:          conf,
+			body:          `[{"query": "{ __typename }", "variables": {"padding": "` + strings.Repeat("x", batchMaxBodySize) + `"}}]`,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+			wantResponses: 1,
+			wantReasons:   []string{"BATCH_LIMIT"},
+		},
+		{
+			name:           "operations run up to the concurrency limit",
+			conf:           conf,
+			body:           `[` + strings.TrimSuffix(strings.Repeat(`{"query": "{ __typename }"},`, 4), ",") + `]`,
+			wantStatus:     http.StatusOK,
+			wantResponses:  4,
+			wantReasons:    []string{"", "", "", ""},
+			wantTypenames:  []interface{}{"Query", "Query", "Query", "Query"},
+			wantConcurrent: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &inFlight{}
+			req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			newServer(tt.conf, tracker).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			responses := []response{}
+			if rec.Code == http.StatusOK {
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+			} else {
+				resp := response{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				responses = append(responses, resp)
+			}
+
+			if !assert.Len(t, responses, tt.wantResponses) {
+				return
+			}
+
+			for i, resp := range responses {
+				reason := ""
+				if len(resp.Errors) > 0 {
+					reason, _ = resp.Errors[0].Extensions["reason"].(string)
+				}
+				assert.Equal(t, tt.wantReasons[i], reason, "reason of operation %d", i)
+
+				if tt.wantTypenames != nil {
+					assert.Equal(t, tt.wantTypenames[i], resp.Data["__typename"], "data of operation %d", i)
+					assert.Equal(t, tt.wantTypenames[i] == nil, len(resp.Errors) > 0, "errors of operation %d", i)
+				}
+			}
+
+			if tt.wantConcurrent > 0 {
+				assert.Equal(t, tt.wantConcurrent, tracker.max)
+			}
+		})
+	}
+}
+
+func Test_batchPOST_Supports(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		want        bool
+	}{
+		{name: "json array", method: http.MethodPost, contentType: "application/json", body: "[{}]", want: true},
+		{name: "json array after whitespace", method: http.MethodPost, contentType: "application/json; charset=utf-8", body: " \r\n\t[{}]", want: true},
+		{name: "json object", method: http.MethodPost, contentType: "application/json", body: `{"query": "{ __typename }"}`, want: false},
+		{name: "empty body", method: http.MethodPost, contentType: "application/json", body: "", want: false},
+		{name: "not json", method: http.MethodPost, contentType: "text/plain", body: "[{}]", want: false},
+		{name: "not post", method: http.MethodGet, contentType: "application/json", body: "[{}]", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/query", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			assert.Equal(t, tt.want, batchPOST{}.Supports(req))
+
+			// the single operation transport still reads the whole body
+			raw, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.body, string(raw))
+		})
+	}
+}
+
+func Test_rest_graphqlErrorPresenter(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         func(r *rest) error
+		wantLogged  bool
+		wantMessage string
+		wantCode    interface{}
+		wantStatus  interface{}
+	}{
+		{
+			name: "client error keeps its message",
+			err: func(r *rest) error {
+				return errors.NewWithCode(codes.CodeBadRequest, "username is taken")
+			},
+			wantMessage: "username is taken",
+			wantCode:    int(codes.CodeBadRequest),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name: "wrapped client error is found",
+			err: func(r *rest) error {
+				return fmt.Errorf("resolver: %w", errors.NewWithCode(codes.CodeForbidden, "admin role is required"))
+			},
+			wantMessage: "resolver: admin role is required",
+			wantCode:    int(codes.CodeForbidden),
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name: "sql not found is a client error without the driver message",
+			err: func(r *rest) error {
+				return errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "sql: no rows in result set")
+			},
+			wantMessage: codes.ErrMsgNotFound.BodyEN,
+			wantCode:    int(codes.CodeNotFound),
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name: "sql unique constraint is a client error without the driver message",
+			err: func(r *rest) error {
+				return errors.NewWithCode(codes.CodeSQLUniqueConstraint, "Error 1062: Duplicate entry 'user' for key 'username'")
+			},
+			wantMessage: codes.ErrMsgConflict.BodyEN,
+			wantCode:    int(codes.CodeConflict),
+			wantStatus:  http.StatusConflict,
+		},
+		{
+			name: "internal error is logged and hidden",
+			err: func(r *rest) error {
+				return errors.NewWithCode(codes.CodeSQLRead, "dial tcp 10.0.0.1:3306: connection refused")
+			},
+			wantLogged:  true,
+			wantMessage: codes.ErrMsgInternalServerError.BodyEN,
+			wantCode:    int(codes.CodeSQLRead),
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name: "error without a code is internal",
+			err: func(r *rest) error {
+				return stderrors.New("open /etc/secret: permission denied")
+			},
+			wantLogged:  true,
+			wantMessage: "Unknown error. Please contact admin",
+			wantCode:    int(codes.NoCode),
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name: "panic recovered by the recover func is internal",
+			err: func(r *rest) error {
+				return r.graphqlRecover(context.Background(), "runtime error: invalid memory address")
+			},
+			wantLogged:  true,
+			wantMessage: codes.ErrMsgInternalServerError.BodyEN,
+			wantCode:    int(codes.CodeInternalServerError),
+			wantStatus:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := mock_log.NewMockInterface(ctrl)
+			r := &rest{log: logger}
+
+			logged := []string{}
+			logger.EXPECT().Error(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, obj interface{}) {
+				logged = append(logged, fmt.Sprint(obj))
+			}).AnyTimes()
+
+			err := tt.err(r)
+			// the panic itself is logged by the recover func
+			logged = logged[:0]
+
+			got := r.graphqlErrorPresenter(context.Background(), err)
+
+			assert.Equal(t, tt.wantMessage, got.Message)
+			assert.Equal(t, tt.wantCode, got.Extensions["code"])
+			assert.Equal(t, tt.wantStatus, got.Extensions["httpStatus"])
+			assert.Contains(t, got.Extensions, "requestId")
+			if tt.wantLogged {
+				assert.Len(t, logged, 1, "internal errors are logged")
+			} else {
+				assert.Empty(t, logged, "client errors are not logged")
+			}
+		})
+	}
+}
+
+func Test_rest_graphqlErrorPresenter_validation(t *testing.T) {
+	r := &rest{}
+	got := r.graphqlErrorPresenter(context.Background(), gqlerror.Errorf("Cannot query field \"unknownField\" on type \"Query\"."))
+
+	assert.Equal(t, "Cannot query field \"unknownField\" on type \"Query\".", got.Message)
+	assert.Equal(t, http.StatusUnprocessableEntity, got.Extensions["httpStatus"])
+	assert.NotContains(t, got.Extensions, "code")
+}
+
+func Test_rest_checkWebsocketOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		corsMode string
+		origin   string
+		want     bool
+	}{
+		{name: "same origin", origin: "https://api.example.com", want: true},
+		{name: "same origin in another case", origin: "https://API.example.com", want: true},
+		{name: "no origin is not a browser", origin: "", want: true},
+		{name: "another origin", origin: "https://evil.example.com", want: false},
+		{name: "another port", origin: "https://api.example.com:8443", want: false},
+		{name: "malformed origin", origin: "https://%zz", want: false},
+		{name: "every origin is allowed", corsMode: "allowall", origin: "https://evil.example.com", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &rest{}
+			r.conf.CORS.Mode = tt.corsMode
+
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/query", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			assert.Equal(t, tt.want, r.checkWebsocketOrigin(req))
+		})
+	}
+}
+
+func Test_rest_websocketInit(t *testing.T) {
+	r, _ := newTestGraphqlServer(t, config.GinConfig{})
+
+	accessToken, err := r.jwtAuth.CreateAccessToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+	refreshToken, err := r.jwtAuth.CreateRefreshToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+	forged, err := jwtAuth.Init(jwtAuth.Config{Secret: "forged", AccessTokenExpLimit: time.Hour}).CreateAccessToken(jwtAuth.User{ID: 1})
+	assert.NoError(t, err)
+
+	claim := jwtAuth.Claim{}
+	_, _, err = jwt.NewParser().ParseUnverified(accessToken, &claim)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantUserID    int64
+		wantErr       bool
+	}{
+		{name: "no token stays anonymous", authorization: ""},
+		{name: "bearer token", authorization: "Bearer " + accessToken, wantUserID: 42},
+		{name: "bare token", authorization: accessToken, wantUserID: 42},
+		{name: "malformed token", authorization: "Bearer not-a-token", wantErr: true},
+		{name: "token signed with another secret", authorization: "Bearer " + forged, wantErr: true},
+		{name: "refresh token", authorization: "Bearer " + refreshToken, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _, err := r.websocketInit(context.Background(), transport.InitPayload{"Authorization": tt.authorization})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rest.websocketInit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.NotEqual(t, codes.NoCode, errors.GetCode(err))
+				return
+			}
+
+			userInfo, authErr := r.jwtAuth.GetUserAuthInfo(ctx)
+			if tt.wantUserID == 0 {
+				assert.Error(t, authErr)
+				_, ok := ctx.Deadline()
+				assert.False(t, ok, "an anonymous connection never expires")
+				return
+			}
+			assert.Equal(t, tt.wantUserID, userInfo.User.ID)
+
+			// the connection ends when the token does
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.Equal(t, claim.ExpiresAt.Time, deadline)
+
+			r.websocketClose(ctx, websocket.CloseNormalClosure)
+			assert.ErrorIs(t, ctx.Err(), context.Canceled, "closing the connection releases the deadline")
+		})
+	}
+}
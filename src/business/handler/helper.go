@@ -2,20 +2,43 @@ package handler
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	schema "github.com/adiatma85/exp-golang-graphql/src/business/graphql"
+	"github.com/adiatma85/exp-golang-graphql/src/business/graphql/cache"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
 	"github.com/adiatma85/own-go-sdk/appcontext"
 	"github.com/adiatma85/own-go-sdk/codes"
 	"github.com/adiatma85/own-go-sdk/errors"
 	"github.com/adiatma85/own-go-sdk/header"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/language"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
 )
 
+const (
+	bearerPrefix        string = "bearer "
+	headerGatewaySecret string = "X-Gateway-Secret"
+)
+
+type websocketCancelCtxKey struct{}
+type gatewayCtxKey struct{}
+
 func (r *rest) Ping(ctx *gin.Context) {
 	resp := entity.Ping{
 		Status:  "OK",
@@ -26,13 +49,113 @@ func (r *rest) Ping(ctx *gin.Context) {
 
 // Graphql Handler
 func (r *rest) graphqlHandler() gin.HandlerFunc {
-	h := handler.NewDefaultServer(r.graphql)
+	h := handler.New(r.graphql)
+
+	h.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              r.websocketInit,
+		CloseFunc:             r.websocketClose,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: r.checkWebsocketOrigin,
+		},
+	})
+	h.AddTransport(transport.Options{})
+	h.AddTransport(transport.GET{})
+	if r.conf.GraphQL.Batch.Enabled {
+		h.AddTransport(batchPOST{conf: r.conf.GraphQL.Batch})
+	}
+	h.AddTransport(transport.POST{})
+	h.AddTransport(transport.MultipartForm{
+		// a little headroom over the avatar limit for the operations part of the form
+		MaxUploadSize: entity.AvatarMaxSize + 1<<20,
+		MaxMemory:     entity.AvatarMaxSize,
+	})
+
+	h.SetQueryCache(lru.New(1000))
+
+	if r.isGraphqlToolEnabled(r.conf.GraphQL.Introspection) {
+		h.Use(extension.Introspection{})
+	}
+	h.Use(federatedService{})
+	// after every extension that may switch introspection on
+	h.Use(introspectionDisabled{})
+
+	// trusted documents must resolve the operation before persisted queries may register it
+	if r.conf.Mode == gin.ReleaseMode && r.conf.GraphQL.TrustedDocuments.Enabled {
+		trusted, err := r.loadTrustedDocuments(r.conf.GraphQL.TrustedDocuments.ManifestPath)
+		if err != nil {
+			r.log.Fatal(context.Background(), fmt.Sprintf("failed to load trusted documents: %v", err))
+		}
+		h.Use(trusted)
+	}
+
+	h.Use(extension.AutomaticPersistedQuery{
+		Cache: cache.Init(cache.InitParam{
+			Type:   r.conf.GraphQL.PersistedQuery.Cache,
+			Size:   r.conf.GraphQL.PersistedQuery.Size,
+			TTL:    r.conf.GraphQL.PersistedQuery.TTL,
+			Prefix: "apq:",
+			Log:    r.log,
+			Redis:  r.redis,
+		}),
+	})
+
+	if r.graphqlMetrics != nil {
+		h.Use(r.graphqlMetrics)
+	}
+
+	h.SetErrorPresenter(r.graphqlErrorPresenter)
+	h.SetRecoverFunc(r.graphqlRecover)
+	h.Use(operationLoaders{uc: r.uc})
+	h.Use(&queryLimit{conf: r.conf.GraphQL})
+	h.Use(schema.ConstraintValidation{})
+	h.Use(r.cacheControl())
 
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if r.isGatewayRequest(c.Request) {
+			ctx = context.WithValue(ctx, gatewayCtxKey{}, true)
+		}
+		// only GET responses are cacheable by clients and proxies, the policy header is left out of any other
+		if c.Request.Method == http.MethodGet {
+			ctx = context.WithValue(ctx, cacheHeaderCtxKey{}, c.Writer.Header())
+		}
+		c.Request = c.Request.WithContext(ctx)
+
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
 
+// isGatewayRequest authenticates the federation gateway, it is never trusted without a secret
+func (r *rest) isGatewayRequest(req *http.Request) bool {
+	conf := r.conf.GraphQL.Federation
+	if !conf.Enabled || conf.Secret == "" {
+		return false
+	}
+
+	secret := req.Header.Get(headerGatewaySecret)
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(conf.Secret)) == 1
+}
+
+// checkWebsocketOrigin follows the CORS mode, only same origin upgrades are allowed unless every origin is
+func (r *rest) checkWebsocketOrigin(req *http.Request) bool {
+	if r.conf.CORS.Mode == "allowall" {
+		return true
+	}
+
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, req.Host)
+}
+
 // Playground Handler
 func (r *rest) playgroundHandler() gin.HandlerFunc {
 	h := playground.Handler("GraphQL", "/query")
@@ -42,7 +165,49 @@ func (r *rest) playgroundHandler() gin.HandlerFunc {
 	}
 }
 
+// isGraphqlToolEnabled keeps the playground and introspection open while developing,
+// in release mode they have to be switched on explicitly
+func (r *rest) isGraphqlToolEnabled(enabled bool) bool {
+	return r.conf.Mode != gin.ReleaseMode || enabled
+}
+
+// basicAuth guards a route only when credentials are configured
+func (r *rest) basicAuth(conf config.BasicAuthConf) gin.HandlerFunc {
+	if conf.Username == "" {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	return gin.BasicAuth(gin.Accounts{conf.Username: conf.Password})
+}
+
+// addFieldsToContext stores the request id and the preferred language so
+// both the REST and the GraphQL error responses can be traced and localized
+func (r *rest) addFieldsToContext(ctx *gin.Context) {
+	reqID := ctx.GetHeader(header.KeyRequestID)
+	if reqID == "" {
+		reqID = generateRequestID()
+	}
+
+	c := ctx.Request.Context()
+	c = appcontext.SetRequestId(c, reqID)
+	c = appcontext.SetAcceptLanguage(c, parseAcceptLanguage(ctx.GetHeader(header.KeyAcceptLanguage)))
+	c = appcontext.SetUserAgent(c, ctx.GetHeader(header.KeyUserAgent))
+	ctx.Request = ctx.Request.WithContext(c)
+
+	ctx.Header(header.KeyRequestID, reqID)
+	ctx.Next()
+}
+
 func (r *rest) SetTimeout(ctx *gin.Context) {
+	// websocket connections outlive any request timeout, subscriptions end with the connection
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		ctx.Request = ctx.Request.WithContext(appcontext.SetRequestStartTime(ctx.Request.Context(), time.Now()))
+		ctx.Next()
+		return
+	}
+
 	// wrap the request context with a timeout
 	c, cancel := context.WithTimeout(ctx.Request.Context(), r.conf.Timeout)
 
@@ -57,6 +222,122 @@ func (r *rest) SetTimeout(ctx *gin.Context) {
 
 }
 
+// OptionalAuth validates the bearer access token when it is present and stores the
+// user identity in the request context. Requests without the header pass through
+// anonymously so public operations like login and register keep working.
+func (r *rest) OptionalAuth(ctx *gin.Context) {
+	authHeader := ctx.GetHeader(header.KeyAuthorization)
+	if authHeader == "" {
+		ctx.Next()
+		return
+	}
+
+	token, err := r.extractBearerToken(authHeader)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+
+	c, err := r.authenticate(ctx.Request.Context(), token)
+	if err != nil {
+		r.httpRespError(ctx, err)
+		return
+	}
+	ctx.Request = ctx.Request.WithContext(c)
+
+	ctx.Next()
+}
+
+// authenticate validates the access token and stores the user identity in the context
+func (r *rest) authenticate(ctx context.Context, token string) (context.Context, error) {
+	user, err := r.jwtAuth.ValidateAccessToken(token)
+	if err != nil {
+		if errors.GetCode(err) == codes.NoCode {
+			err = errors.NewWithCode(codes.CodeAuthInvalidToken, err.Error())
+		}
+		return ctx, err
+	}
+
+	ctx = r.jwtAuth.SetUserAuthInfo(ctx, jwtAuth.UserAuthParam{User: user})
+	ctx = appcontext.SetAuthToken(ctx, token)
+	ctx = appcontext.SetUserId(ctx, int(user.ID))
+
+	return ctx, nil
+}
+
+// websocketInit authenticates subscriptions with the authorization of the connection init payload,
+// browsers cannot set headers on a websocket upgrade. Connections without one stay anonymous.
+func (r *rest) websocketInit(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	token := strings.TrimSpace(initPayload.Authorization())
+	if token == "" {
+		return ctx, nil, nil
+	}
+
+	if len(token) > len(bearerPrefix) && strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		token = strings.TrimSpace(token[len(bearerPrefix):])
+	}
+
+	ctx, err := r.authenticate(ctx, token)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	// the token is checked once per connection, the connection is closed when it expires so
+	// subscriptions never outlive the authorization they started with
+	claim := jwtAuth.Claim{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claim); err != nil {
+		return ctx, nil, errors.NewWithCode(codes.CodeAuthInvalidToken, err.Error())
+	}
+	if claim.ExpiresAt == nil {
+		return ctx, nil, nil
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, claim.ExpiresAt.Time)
+	ctx = context.WithValue(ctx, websocketCancelCtxKey{}, cancel)
+	ctx = transport.AppendCloseReason(ctx, "access token expired")
+
+	return ctx, nil, nil
+}
+
+// websocketClose releases the token deadline of the connection
+func (r *rest) websocketClose(ctx context.Context, closeCode int) {
+	if cancel, ok := ctx.Value(websocketCancelCtxKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+func (r *rest) extractBearerToken(authHeader string) (string, error) {
+	if len(authHeader) <= len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return "", errors.NewWithCode(codes.CodeAuthInvalidToken, "invalid authorization header format")
+	}
+
+	return strings.TrimSpace(authHeader[len(bearerPrefix):]), nil
+}
+
+// parseAcceptLanguage picks the first supported language of the header, english is the default
+func parseAcceptLanguage(acceptLanguage string) string {
+	for _, tag := range strings.Split(acceptLanguage, ",") {
+		tag = strings.ToLower(strings.TrimSpace(strings.SplitN(tag, ";", 2)[0]))
+		switch strings.SplitN(tag, "-", 2)[0] {
+		case language.Indonesian:
+			return language.Indonesian
+		case language.English:
+			return language.English
+		}
+	}
+
+	return language.English
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
+
 func (r *rest) httpRespSuccess(ctx *gin.Context, code codes.Code, data interface{}, p *entity.Pagination) {
 	successApp := codes.Compile(code, appcontext.GetAcceptLanguage(ctx))
 	c := ctx.Request.Context()
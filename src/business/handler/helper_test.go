@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/own-go-sdk/appcontext"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_rest_OptionalAuth(t *testing.T) {
+	r, _ := newTestGraphqlServer(t, config.GinConfig{})
+
+	accessToken, err := r.jwtAuth.CreateAccessToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+	refreshToken, err := r.jwtAuth.CreateRefreshToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+	expired, err := jwtAuth.Init(jwtAuth.Config{Secret: "secret", AccessTokenExpLimit: -time.Minute}).CreateAccessToken(jwtAuth.User{ID: 42})
+	assert.NoError(t, err)
+	forged, err := jwtAuth.Init(jwtAuth.Config{Secret: "forged", AccessTokenExpLimit: time.Hour}).CreateAccessToken(jwtAuth.User{ID: 1})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantUserID    int64
+		wantStatus    int
+	}{
+		{
+			name:       "no header passes through anonymously",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "bearer token",
+			authorization: "Bearer " + accessToken,
+			wantUserID:    42,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "bearer prefix in any case",
+			authorization: "bearer " + accessToken,
+			wantUserID:    42,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "token without the bearer prefix",
+			authorization: accessToken,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "other authorization scheme",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "bearer prefix without a token",
+			authorization: "Bearer ",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "malformed token is never downgraded to anonymous",
+			authorization: "Bearer not-a-token",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "expired token is never downgraded to anonymous",
+			authorization: "Bearer " + expired,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "token signed with another secret",
+			authorization: "Bearer " + forged,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "refresh token",
+			authorization: "Bearer " + refreshToken,
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			var gotUserID int64
+			var authErr error
+
+			engine := gin.New()
+			engine.GET("/", r.OptionalAuth, func(ctx *gin.Context) {
+				called = true
+				userInfo, err := r.jwtAuth.GetUserAuthInfo(ctx.Request.Context())
+				gotUserID, authErr = userInfo.User.ID, err
+				assert.Equal(t, int(gotUserID), appcontext.GetUserId(ctx.Request.Context()))
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				assert.False(t, called, "a rejected request never reaches the handler")
+
+				resp := entity.HTTPResp{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				if assert.NotNil(t, resp.Meta.Error) {
+					assert.Equal(t, int(codes.CodeAuthInvalidToken), resp.Meta.Error.Code)
+				}
+				return
+			}
+
+			assert.True(t, called)
+			if tt.wantUserID == 0 {
+				assert.Error(t, authErr, "an anonymous request has no user")
+				return
+			}
+			assert.NoError(t, authErr)
+			assert.Equal(t, tt.wantUserID, gotUserID)
+		})
+	}
+}
+
+func Test_rest_extractBearerToken(t *testing.T) {
+	r := &rest{}
+
+	tests := []struct {
+		name       string
+		authHeader string
+		want       string
+		wantErr    bool
+	}{
+		{name: "bearer token", authHeader: "Bearer token", want: "token"},
+		{name: "lower case prefix", authHeader: "bearer token", want: "token"},
+		{name: "surrounding spaces are trimmed", authHeader: "Bearer   token  ", want: "token"},
+		{name: "missing prefix", authHeader: "token", wantErr: true},
+		{name: "prefix only", authHeader: "Bearer ", wantErr: true},
+		{name: "other scheme", authHeader: "Basic dXNlcjpwYXNz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.extractBearerToken(tt.authHeader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rest.extractBearerToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeAuthInvalidToken, errors.GetCode(err))
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
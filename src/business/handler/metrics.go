@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	// operation names are chosen by clients, names seen after the limit are recorded as other
+	maxOperationNames   = 500
+	operationAnonymous  = "anonymous"
+	operationOtherNames = "other"
+)
+
+// graphqlMetrics records operation and resolver latencies, the operation name and type are labels
+// so dashboards can tell which operations are slow and which resolvers make them slow. Only metrics
+// are recorded, tracing is not delivered since the instrument has no tracer to record spans with.
+type graphqlMetrics struct {
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+	resolverDuration  *prometheus.HistogramVec
+
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = &graphqlMetrics{}
+
+func newGraphqlMetrics(registerer prometheus.Registerer) *graphqlMetrics {
+	m := &graphqlMetrics{
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "graphql_operation_duration_seconds",
+			Help: "Duration of GraphQL operations",
+		}, []string{"operation_name", "operation_type"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "graphql_operation_errors_total",
+			Help: "Number of errors returned by GraphQL operations",
+		}, []string{"operation_name", "operation_type"}),
+		resolverDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "graphql_resolver_duration_seconds",
+			Help:    "Duration of GraphQL field resolvers",
+			Buckets: []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"object", "field"}),
+		names: map[string]struct{}{},
+	}
+
+	registerer.MustRegister(m.operationDuration, m.operationErrors, m.resolverDuration)
+
+	return m
+}
+
+func (m *graphqlMetrics) ExtensionName() string {
+	return "Metrics"
+}
+
+func (m *graphqlMetrics) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (m *graphqlMetrics) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation == nil {
+		return resp
+	}
+
+	name := m.operationName(rc)
+	operationType := string(rc.Operation.Operation)
+
+	// every event of a subscription is a response, their duration says nothing about the operation
+	if rc.Operation.Operation != ast.Subscription {
+		m.operationDuration.WithLabelValues(name, operationType).Observe(time.Since(rc.Stats.OperationStart).Seconds())
+	}
+
+	if resp != nil && len(resp.Errors) > 0 {
+		m.operationErrors.WithLabelValues(name, operationType).Add(float64(len(resp.Errors)))
+	}
+
+	return resp
+}
+
+func (m *graphqlMetrics) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+
+	// plain struct fields cost nothing, only resolvers are worth a histogram
+	if fc == nil || !(fc.IsResolver || fc.IsMethod) {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	res, err := next(ctx)
+	m.resolverDuration.WithLabelValues(fc.Object, fc.Field.Name).Observe(time.Since(start).Seconds())
+
+	return res, err
+}
+
+func (m *graphqlMetrics) operationName(rc *graphql.OperationContext) string {
+	name := rc.OperationName
+	if name == "" {
+		name = rc.Operation.Name
+	}
+
+	if name == "" {
+		return operationAnonymous
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.names[name]; !ok {
+		if len(m.names) >= maxOperationNames {
+			return operationOtherNames
+		}
+		m.names[name] = struct{}{}
+	}
+
+	return name
+}
+
+// metricsHandler serves the instrument metrics followed by the graphql metrics, so the path already
+// scraped for the service has both. The instrument keeps its registry private, both are written in
+// the text format and their metric names never overlap.
+func metricsHandler(instrument http.Handler, gatherer prometheus.Gatherer) http.Handler {
+	graphqlHandler := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// protobuf, openmetrics and gzip bodies cannot be concatenated
+		textReq := req.Clone(req.Context())
+		textReq.Header.Set("Accept", "text/plain")
+		textReq.Header.Del("Accept-Encoding")
+
+		body := bytes.Buffer{}
+		for _, h := range []http.Handler{instrument, graphqlHandler} {
+			resp := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+			h.ServeHTTP(resp, textReq)
+			if resp.status != http.StatusOK {
+				http.Error(w, resp.body.String(), resp.status)
+				return
+			}
+			body.Write(resp.body.Bytes())
+		}
+
+		w.Header().Set("Content-Type", metricsContentType)
+		_, _ = w.Write(body.Bytes())
+	})
+}
+
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// bufferedResponse keeps a response in memory until every part of it is written
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
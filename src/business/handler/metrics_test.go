@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// withOperation returns ctx as it is while the response of the operation is written
+func withOperation(operationType ast.Operation, name string) context.Context {
+	return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Operation: &ast.OperationDefinition{Operation: operationType, Name: name},
+		Stats:     graphql.Stats{OperationStart: time.Now()},
+	})
+}
+
+func Test_graphqlMetrics_operationName(t *testing.T) {
+	m := newGraphqlMetrics(prometheus.NewRegistry())
+
+	for i := 0; i < maxOperationNames; i++ {
+		name := fmt.Sprintf("Operation%d", i)
+		assert.Equal(t, name, m.operationName(&graphql.OperationContext{Operation: &ast.OperationDefinition{Name: name}}))
+	}
+
+	// names seen before the limit keep their label, new names share one
+	assert.Equal(t, "Operation0", m.operationName(&graphql.OperationContext{Operation: &ast.OperationDefinition{Name: "Operation0"}}))
+	assert.Equal(t, operationOtherNames, m.operationName(&graphql.OperationContext{Operation: &ast.OperationDefinition{Name: "Operation500"}}))
+	assert.Len(t, m.names, maxOperationNames)
+
+	// the name sent with the request wins over the one of the document
+	assert.Equal(t, "Operation1", m.operationName(&graphql.OperationContext{OperationName: "Operation1", Operation: &ast.OperationDefinition{Name: "Operation500"}}))
+	assert.Equal(t, operationAnonymous, m.operationName(&graphql.OperationContext{Operation: &ast.OperationDefinition{}}))
+}
+
+func Test_graphqlMetrics_InterceptResponse(t *testing.T) {
+	m := newGraphqlMetrics(prometheus.NewRegistry())
+
+	respond := func(errs gqlerror.List) graphql.ResponseHandler {
+		return func(ctx context.Context) *graphql.Response {
+			return &graphql.Response{Errors: errs}
+		}
+	}
+
+	m.InterceptResponse(withOperation(ast.Query, "GetUser"), respond(nil))
+	m.InterceptResponse(withOperation(ast.Query, "GetUser"), respond(gqlerror.List{{Message: "a"}, {Message: "b"}}))
+	m.InterceptResponse(withOperation(ast.Mutation, ""), respond(gqlerror.List{{Message: "a"}}))
+	m.InterceptResponse(withOperation(ast.Subscription, "OnUser"), respond(nil))
+
+	// every error of a response is counted
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.operationErrors.WithLabelValues("GetUser", "query")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.operationErrors.WithLabelValues(operationAnonymous, "mutation")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.operationErrors))
+
+	// subscriptions have no duration, their responses are events
+	assert.Equal(t, 2, testutil.CollectAndCount(m.operationDuration))
+}
+
+func Test_graphqlMetrics_InterceptField(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := newGraphqlMetrics(registry)
+
+	field := func(object, name string, isResolver bool) context.Context {
+		return graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+			Object:     object,
+			Field:      graphql.CollectedField{Field: &ast.Field{Name: name}},
+			IsResolver: isResolver,
+		})
+	}
+	next := func(ctx context.Context) (interface{}, error) {
+		return "resolved", nil
+	}
+
+	res, err := m.InterceptField(field("Query", "user", true), next)
+	assert.NoError(t, err)
+	assert.Equal(t, "resolved", res)
+
+	_, _ = m.InterceptField(field("User", "role", true), next)
+	_, _ = m.InterceptField(field("User", "role", true), next)
+	// plain struct fields are not observed
+	_, _ = m.InterceptField(field("User", "email", false), next)
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+
+	got := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "graphql_resolver_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			got[labels["object"]+"."+labels["field"]] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+	assert.Equal(t, map[string]uint64{"Query.user": 1, "User.role": 2}, got)
+}
+
+func Test_metricsHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := newGraphqlMetrics(registry)
+	m.InterceptResponse(withOperation(ast.Query, "GetUser"), func(ctx context.Context) *graphql.Response {
+		return &graphql.Response{Errors: gqlerror.List{{Message: "a"}}}
+	})
+
+	tests := []struct {
+		name       string
+		instrument http.Handler
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name: "both registries",
+			instrument: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "text/plain", r.Header.Get("Accept"), "only the text format can be concatenated")
+				assert.Empty(t, r.Header.Get("Accept-Encoding"))
+				_, _ = w.Write([]byte("# TYPE http_requests_total counter\nhttp_requests_total 3\n"))
+			}),
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				"http_requests_total 3\n",
+				`graphql_operation_errors_total{operation_name="GetUser",operation_type="query"} 1`,
+			},
+		},
+		{
+			name: "instrument failing",
+			instrument: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "gathering failed", http.StatusInternalServerError)
+			}),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"gathering failed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.Header.Set("Accept", "application/vnd.google.protobuf")
+			req.Header.Set("Accept-Encoding", "gzip")
+			rec := httptest.NewRecorder()
+
+			metricsHandler(tt.instrument, registry).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, rec.Body.String(), want)
+			}
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, metricsContentType, rec.Header().Get("Content-Type"))
+				assert.True(t, strings.Index(rec.Body.String(), "http_requests_total") < strings.Index(rec.Body.String(), "graphql_"))
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/adiatma85/exp-golang-graphql/src/business/usecase"
 	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
 	"github.com/adiatma85/own-go-sdk/appcontext"
 	"github.com/adiatma85/own-go-sdk/codes"
 	"github.com/adiatma85/own-go-sdk/errors"
@@ -22,8 +23,10 @@ import (
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
 	"github.com/adiatma85/own-go-sdk/log"
 	"github.com/adiatma85/own-go-sdk/parser"
+	"github.com/adiatma85/own-go-sdk/redis"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
@@ -46,6 +49,10 @@ type rest struct {
 	instrument instrument.Interface
 	jwtAuth    jwtAuth.Interface
 	graphql    graphql.ExecutableSchema
+	redis      redis.Interface
+	storage    storage.Config
+	// graphqlMetrics is nil unless the metrics are enabled
+	graphqlMetrics *graphqlMetrics
 }
 
 type InitParam struct {
@@ -57,6 +64,10 @@ type InitParam struct {
 	Instrument instrument.Interface
 	JwtAuth    jwtAuth.Interface
 	Graphql    graphql.ExecutableSchema
+	// Redis is optional, it is nil unless a feature is configured to use it
+	Redis redis.Interface
+	// Storage is only used to serve the local storage directory
+	Storage storage.Config
 }
 
 func Init(param InitParam) REST {
@@ -83,6 +94,8 @@ func Init(param InitParam) REST {
 			instrument: param.Instrument,
 			jwtAuth:    param.JwtAuth,
 			graphql:    param.Graphql,
+			redis:      param.Redis,
+			storage:    param.Storage,
 		}
 
 		// Set CORS
@@ -104,6 +117,9 @@ func Init(param InitParam) REST {
 			r.http.Use(cors.New(cors.DefaultConfig()))
 		}
 
+		// Set Request Id and Language
+		r.http.Use(r.addFieldsToContext)
+
 		// Set Timeout
 		r.http.Use(r.SetTimeout)
 
@@ -148,9 +164,36 @@ func (r *rest) Register() {
 	// Server health and testing purpose
 	r.http.GET("/ping", r.Ping)
 
-	// Server Graphql
-	r.http.POST("/query", r.graphqlHandler())
-	r.http.GET("/", r.playgroundHandler())
+	// Server metrics, the graphql metrics are served together with the ones of the instrument
+	if r.instrument.IsEnabled() && r.conf.Instrument.Metrics.Enabled {
+		registry := prometheus.NewRegistry()
+		r.graphqlMetrics = newGraphqlMetrics(registry)
+
+		path := r.conf.Instrument.Metrics.Path
+		if path == "" {
+			path = "/metrics"
+		}
+		r.http.GET(path, r.basicAuth(r.conf.Instrument.Metrics.BasicAuth), gin.WrapH(metricsHandler(r.instrument.MetricsHandler(), registry)))
+	}
+
+	// Server uploaded files, object stores serve their own
+	if r.storage.Type == storage.TypeLocal && r.storage.Local.Route != "" {
+		r.http.Static(r.storage.Local.Route, r.storage.Local.Dir)
+	}
+
+	// Server Graphql, GET only serves queries so persisted reads can be cached by a CDN
+	graphqlHandler := r.graphqlHandler()
+	r.http.POST("/query", r.OptionalAuth, graphqlHandler)
+	r.http.GET("/query", r.OptionalAuth, graphqlHandler)
+
+	// Server Graphql Playground
+	if r.isGraphqlToolEnabled(r.conf.GraphQL.Playground.Enabled) {
+		path := r.conf.GraphQL.Playground.Path
+		if path == "" {
+			path = "/"
+		}
+		r.http.GET(path, r.basicAuth(r.conf.GraphQL.Playground.BasicAuth), r.playgroundHandler())
+	}
 }
 
 func (r *rest) Run() {
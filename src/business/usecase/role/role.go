@@ -0,0 +1,300 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	roleDom "github.com/adiatma85/exp-golang-graphql/src/business/domain/role"
+	userDom "github.com/adiatma85/exp-golang-graphql/src/business/domain/user"
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/query"
+)
+
+type Interface interface {
+	// Admin Functionality
+	Create(ctx context.Context, req entity.CreateRoleParam) (entity.Role, error)
+	Get(ctx context.Context, params entity.RoleParam) (entity.Role, error)
+	GetList(ctx context.Context, params entity.RoleParam) ([]entity.Role, *entity.Pagination, error)
+	Update(ctx context.Context, updateParam entity.UpdateRoleParam, selectParam entity.RoleParam) error
+	Delete(ctx context.Context, selectParam entity.RoleParam) error
+	Activate(ctx context.Context, selectParam entity.RoleParam) error
+
+	// Internal Functionality, used to resolve roles of already authorized objects
+	GetListByIDs(ctx context.Context, ids []int64) ([]entity.Role, error)
+	// GetAuthRole returns the active role of the authenticated user, used by the schema directives
+	GetAuthRole(ctx context.Context) (entity.Role, error)
+	// CheckAdmin returns the authenticated user when it has an admin role, used by the admin usecases
+	CheckAdmin(ctx context.Context) (jwtAuth.UserAuthInfo, error)
+	// CheckAssignRole makes sure the authenticated user may grant the role, used when a user changes role
+	CheckAssignRole(ctx context.Context, roleID int64) error
+}
+
+type InitParam struct {
+	Log     log.Interface
+	Role    roleDom.Interface
+	User    userDom.Interface
+	JwtAuth jwtAuth.Interface
+}
+
+type role struct {
+	log     log.Interface
+	role    roleDom.Interface
+	user    userDom.Interface
+	jwtAuth jwtAuth.Interface
+}
+
+var Now = time.Now
+
+func Init(param InitParam) Interface {
+	r := &role{
+		log:     param.Log,
+		role:    param.Role,
+		user:    param.User,
+		jwtAuth: param.JwtAuth,
+	}
+
+	return r
+}
+
+func (r *role) Create(ctx context.Context, req entity.CreateRoleParam) (entity.Role, error) {
+	var result entity.Role
+
+	userInfo, err := r.CheckAdmin(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if err := r.validateRole(req.Type); err != nil {
+		return result, err
+	}
+
+	req.CreatedBy = null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID))
+	req.UpdatedBy = null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID))
+
+	return r.role.Create(ctx, req)
+}
+
+func (r *role) Get(ctx context.Context, params entity.RoleParam) (entity.Role, error) {
+	if _, err := r.CheckAdmin(ctx); err != nil {
+		return entity.Role{}, err
+	}
+
+	return r.role.Get(ctx, params)
+}
+
+func (r *role) GetList(ctx context.Context, params entity.RoleParam) ([]entity.Role, *entity.Pagination, error) {
+	if _, err := r.CheckAdmin(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	params.IncludePagination = true
+	roles, pg, err := r.role.GetList(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return roles, pg, nil
+}
+
+func (r *role) Update(ctx context.Context, updateParam entity.UpdateRoleParam, selectParam entity.RoleParam) error {
+	userInfo, err := r.CheckAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if updateParam.Type != "" {
+		if err := r.validateRole(updateParam.Type); err != nil {
+			return err
+		}
+	}
+
+	// The super admin role keeps its type and rank, only its name can change
+	if isSuperAdminRole(selectParam) && (updateParam.Type != "" || updateParam.Rank != 0 || updateParam.Status.Valid) {
+		return errors.NewWithCode(codes.CodeForbidden, "super admin role type and rank can not be changed")
+	}
+
+	updateParam.UpdatedAt = null.TimeFrom(Now())
+	updateParam.UpdatedBy = null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID))
+
+	return r.role.Update(ctx, updateParam, selectParam)
+}
+
+func (r *role) Delete(ctx context.Context, selectParam entity.RoleParam) error {
+	userInfo, err := r.CheckAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The super admin role must always exist
+	if isSuperAdminRole(selectParam) {
+		return errors.NewWithCode(codes.CodeForbidden, "super admin role can not be deleted")
+	}
+
+	deleteParam := entity.UpdateRoleParam{
+		Status:    null.Int64From(-1),
+		DeletedAt: null.TimeFrom(Now()),
+		DeletedBy: null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID)),
+	}
+
+	return r.role.Update(ctx, deleteParam, selectParam)
+}
+
+func (r *role) Activate(ctx context.Context, selectParam entity.RoleParam) error {
+	userInfo, err := r.CheckAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	activateParam := entity.UpdateRoleParam{
+		Status:    null.Int64From(1),
+		UpdatedAt: null.TimeFrom(Now()),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID)),
+	}
+
+	return r.role.Update(ctx, activateParam, selectParam)
+}
+
+func (r *role) GetListByIDs(ctx context.Context, ids []int64) ([]entity.Role, error) {
+	if len(ids) == 0 {
+		return []entity.Role{}, nil
+	}
+
+	roles, _, err := r.role.GetList(ctx, entity.RoleParam{
+		IDs: ids,
+		QueryOption: query.Option{
+			DisableLimit: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// isSuperAdminRole tells whether the select param may match the super admin role
+func isSuperAdminRole(selectParam entity.RoleParam) bool {
+	if selectParam.ID.Valid {
+		return selectParam.ID.Int64 == entity.RoleIdSuperAdmin
+	}
+
+	for _, id := range selectParam.IDs {
+		if id == entity.RoleIdSuperAdmin {
+			return true
+		}
+	}
+
+	// without an id any role may match, the super admin role included
+	return true
+}
+
+func (r *role) validateRole(roleType string) error {
+	switch roleType {
+	case entity.RoleTypeAdmin, entity.RoleTypeUser:
+		return nil
+	default:
+		return errors.NewWithCode(codes.CodeBadRequest, "role type must be either %s or %s", entity.RoleTypeAdmin, entity.RoleTypeUser)
+	}
+}
+
+// GetAuthRole returns the role of the current user, the user has to be active
+func (r *role) GetAuthRole(ctx context.Context) (entity.Role, error) {
+	userInfo, err := r.jwtAuth.GetUserAuthInfo(ctx)
+	if err != nil {
+		return entity.Role{}, err
+	}
+
+	return r.getAuthRole(ctx, userInfo.User.ID)
+}
+
+// CheckAdmin makes sure the current user is an active user with an admin role
+func (r *role) CheckAdmin(ctx context.Context) (jwtAuth.UserAuthInfo, error) {
+	userInfo, err := r.jwtAuth.GetUserAuthInfo(ctx)
+	if err != nil {
+		return userInfo, err
+	}
+
+	userRole, err := r.getAuthRole(ctx, userInfo.User.ID)
+	if err != nil {
+		return userInfo, err
+	}
+
+	if userRole.ID != entity.RoleIdSuperAdmin && userRole.Type != entity.RoleTypeAdmin {
+		return userInfo, errors.NewWithCode(codes.CodeForbidden, "admin role is required")
+	}
+
+	return userInfo, nil
+}
+
+// CheckAssignRole only allows granting an active role ranked below the role of the current user,
+// the super admin role is never granted so no admin can raise anyone to or above itself
+func (r *role) CheckAssignRole(ctx context.Context, roleID int64) error {
+	if roleID == entity.RoleIdSuperAdmin {
+		return errors.NewWithCode(codes.CodeForbidden, "super admin role can not be assigned")
+	}
+
+	authRole, err := r.GetAuthRole(ctx)
+	if err != nil {
+		return err
+	}
+
+	assignedRole, err := r.role.Get(ctx, entity.RoleParam{
+		ID: null.Int64From(roleID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return errors.NewWithCode(codes.CodeBadRequest, "role is not found")
+		}
+		return err
+	}
+
+	if assignedRole.Rank >= authRole.Rank {
+		return errors.NewWithCode(codes.CodeForbidden, "role must rank below the role of the current user")
+	}
+
+	return nil
+}
+
+func (r *role) getAuthRole(ctx context.Context, userID int64) (entity.Role, error) {
+	user, err := r.user.Get(ctx, entity.UserParam{
+		ID: null.Int64From(userID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return entity.Role{}, errors.NewWithCode(codes.CodeAuthFailure, "user is not found")
+		}
+		return entity.Role{}, err
+	}
+
+	userRole, err := r.role.Get(ctx, entity.RoleParam{
+		ID: null.Int64From(user.RoleId.Int64),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil {
+		if errors.GetCode(err) != codes.CodeSQLRecordDoesNotExist {
+			return userRole, err
+		}
+
+		// an inactive or missing role grants nothing, except for the built in super admin
+		userRole = entity.Role{ID: user.RoleId.Int64}
+		if userRole.ID == entity.RoleIdSuperAdmin {
+			userRole.Type = entity.RoleTypeAdmin
+		}
+	}
+
+	return userRole, nil
+}
@@ -0,0 +1,479 @@
+package role
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	mock_role "github.com/adiatma85/exp-golang-graphql/tests/mock/domain/role"
+	mock_user "github.com/adiatma85/exp-golang-graphql/tests/mock/domain/user"
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/jwtAuth"
+	"github.com/adiatma85/own-go-sdk/null"
+	"github.com/adiatma85/own-go-sdk/query"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/mock/gomock"
+)
+
+type mocks struct {
+	role *mock_role.MockInterface
+	user *mock_user.MockInterface
+}
+
+func newTestRole(t *testing.T) (*role, mocks) {
+	ctrl := gomock.NewController(t)
+
+	m := mocks{
+		role: mock_role.NewMockInterface(ctrl),
+		user: mock_user.NewMockInterface(ctrl),
+	}
+
+	r := Init(InitParam{
+		Log:     mock_log.NewMockInterface(ctrl),
+		Role:    m.role,
+		User:    m.user,
+		JwtAuth: jwtAuth.Init(jwtAuth.Config{Secret: "secret"}),
+	}).(*role)
+
+	return r, m
+}
+
+// authCtx is the context of a request authenticated as the given user
+func authCtx(r *role, userID int64) context.Context {
+	return r.jwtAuth.SetUserAuthInfo(context.Background(), jwtAuth.UserAuthParam{User: jwtAuth.User{ID: userID}})
+}
+
+// expectAuthRole makes the authenticated user 42 an active user of the given role
+func expectAuthRole(m mocks, authRole entity.Role, roleErr error) {
+	m.user.EXPECT().Get(gomock.Any(), entity.UserParam{
+		ID:          null.Int64From(42),
+		QueryOption: query.Option{IsActive: true},
+	}).Return(entity.User{ID: 42, RoleId: null.Int64From(authRole.ID)}, nil)
+	m.role.EXPECT().Get(gomock.Any(), entity.RoleParam{
+		ID:          null.Int64From(authRole.ID),
+		QueryOption: query.Option{IsActive: true},
+	}).Return(authRole, roleErr)
+}
+
+func Test_role_CheckAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      func(r *role) context.Context
+		mockFunc func(m mocks)
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name:     "anonymous caller",
+			ctx:      func(r *role) context.Context { return context.Background() },
+			mockFunc: func(m mocks) {},
+			wantCode: codes.CodeAuthFailure,
+			wantErr:  true,
+		},
+		{
+			name: "inactive user",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				m.user.EXPECT().Get(gomock.Any(), gomock.Any()).
+					Return(entity.User{}, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "not found"))
+			},
+			wantCode: codes.CodeAuthFailure,
+			wantErr:  true,
+		},
+		{
+			name: "user role",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 3, Type: entity.RoleTypeUser, Rank: 100}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name: "inactive admin role grants nothing",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2}, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "not found"))
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name: "failing role lookup denies",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2}, errors.NewWithCode(codes.CodeSQLRead, "connection reset"))
+			},
+			wantCode: codes.CodeSQLRead,
+			wantErr:  true,
+		},
+		{
+			name: "admin role",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "super admin role even when it is inactive",
+			ctx:  func(r *role) context.Context { return authCtx(r, 42) },
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: entity.RoleIdSuperAdmin}, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "not found"))
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, m := newTestRole(t)
+			tt.mockFunc(m)
+
+			userInfo, err := r.CheckAdmin(tt.ctx(r))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.CheckAdmin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			}
+			if !tt.wantErr {
+				assert.Equal(t, int64(42), userInfo.User.ID)
+			}
+		})
+	}
+}
+
+func Test_role_Delete(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		selectParam entity.RoleParam
+		mockFunc    func(m mocks)
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name:        "super admin role cannot be deleted",
+			selectParam: entity.RoleParam{ID: null.Int64From(entity.RoleIdSuperAdmin)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: entity.RoleIdSuperAdmin, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:        "non admin cannot delete a role",
+			selectParam: entity.RoleParam{ID: null.Int64From(3)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 3, Type: entity.RoleTypeUser}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:        "role is soft deleted",
+			selectParam: entity.RoleParam{ID: null.Int64From(3)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+				m.role.EXPECT().Update(gomock.Any(), entity.UpdateRoleParam{
+					Status:    null.Int64From(-1),
+					DeletedAt: null.TimeFrom(now),
+					DeletedBy: null.StringFrom("42"),
+				}, entity.RoleParam{ID: null.Int64From(3)}).Return(nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Now = func() time.Time { return now }
+			t.Cleanup(func() { Now = time.Now })
+
+			r, m := newTestRole(t)
+			tt.mockFunc(m)
+
+			err := r.Delete(authCtx(r, 42), tt.selectParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			}
+		})
+	}
+}
+
+func Test_role_Update(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		updateParam entity.UpdateRoleParam
+		selectParam entity.RoleParam
+		mockFunc    func(m mocks)
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name:        "super admin role type cannot be changed",
+			updateParam: entity.UpdateRoleParam{Type: entity.RoleTypeUser},
+			selectParam: entity.RoleParam{ID: null.Int64From(entity.RoleIdSuperAdmin)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:        "super admin role rank cannot be changed",
+			updateParam: entity.UpdateRoleParam{Rank: 1},
+			selectParam: entity.RoleParam{ID: null.Int64From(entity.RoleIdSuperAdmin)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:        "roles selected without an id may include the super admin role",
+			updateParam: entity.UpdateRoleParam{Rank: 1},
+			selectParam: entity.RoleParam{Type: null.StringFrom(entity.RoleTypeAdmin)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:        "invalid role type",
+			updateParam: entity.UpdateRoleParam{Type: "owner"},
+			selectParam: entity.RoleParam{ID: null.Int64From(3)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+			},
+			wantCode: codes.CodeBadRequest,
+			wantErr:  true,
+		},
+		{
+			name:        "super admin role can be renamed",
+			updateParam: entity.UpdateRoleParam{Name: "root"},
+			selectParam: entity.RoleParam{ID: null.Int64From(entity.RoleIdSuperAdmin)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+				m.role.EXPECT().Update(gomock.Any(), entity.UpdateRoleParam{
+					Name:      "root",
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("42"),
+				}, entity.RoleParam{ID: null.Int64From(entity.RoleIdSuperAdmin)}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:        "role type and rank are updated",
+			updateParam: entity.UpdateRoleParam{Type: entity.RoleTypeUser, Rank: 5},
+			selectParam: entity.RoleParam{ID: null.Int64From(3)},
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+				m.role.EXPECT().Update(gomock.Any(), entity.UpdateRoleParam{
+					Type:      entity.RoleTypeUser,
+					Rank:      5,
+					UpdatedAt: null.TimeFrom(now),
+					UpdatedBy: null.StringFrom("42"),
+				}, entity.RoleParam{ID: null.Int64From(3)}).Return(nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Now = func() time.Time { return now }
+			t.Cleanup(func() { Now = time.Now })
+
+			r, m := newTestRole(t)
+			tt.mockFunc(m)
+
+			err := r.Update(authCtx(r, 42), tt.updateParam, tt.selectParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			}
+		})
+	}
+}
+
+func Test_role_CheckAssignRole(t *testing.T) {
+	adminRole := entity.Role{ID: 2, Type: entity.RoleTypeAdmin, Rank: 50}
+	assignedParam := entity.RoleParam{
+		ID:          null.Int64From(3),
+		QueryOption: query.Option{IsActive: true},
+	}
+
+	tests := []struct {
+		name     string
+		roleID   int64
+		mockFunc func(m mocks)
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name:     "super admin role is never assigned",
+			roleID:   entity.RoleIdSuperAdmin,
+			mockFunc: func(m mocks) {},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:   "inactive or missing role",
+			roleID: 3,
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, adminRole, nil)
+				m.role.EXPECT().Get(gomock.Any(), assignedParam).
+					Return(entity.Role{}, errors.NewWithCode(codes.CodeSQLRecordDoesNotExist, "not found"))
+			},
+			wantCode: codes.CodeBadRequest,
+			wantErr:  true,
+		},
+		{
+			name:   "role of the same rank",
+			roleID: 3,
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, adminRole, nil)
+				m.role.EXPECT().Get(gomock.Any(), assignedParam).Return(entity.Role{ID: 3, Rank: 50}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:   "role of a higher rank",
+			roleID: 3,
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, adminRole, nil)
+				m.role.EXPECT().Get(gomock.Any(), assignedParam).Return(entity.Role{ID: 3, Rank: 80}, nil)
+			},
+			wantCode: codes.CodeForbidden,
+			wantErr:  true,
+		},
+		{
+			name:   "role of a lower rank",
+			roleID: 3,
+			mockFunc: func(m mocks) {
+				expectAuthRole(m, adminRole, nil)
+				m.role.EXPECT().Get(gomock.Any(), assignedParam).Return(entity.Role{ID: 3, Rank: 10}, nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, m := newTestRole(t)
+			tt.mockFunc(m)
+
+			err := r.CheckAssignRole(authCtx(r, 42), tt.roleID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.CheckAssignRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+			}
+		})
+	}
+}
+
+func Test_role_validateRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		roleType string
+		wantErr  bool
+	}{
+		{name: "admin", roleType: entity.RoleTypeAdmin, wantErr: false},
+		{name: "user", roleType: entity.RoleTypeUser, wantErr: false},
+		{name: "unknown", roleType: "owner", wantErr: true},
+		{name: "types are case sensitive", roleType: "ADMIN", wantErr: true},
+		{name: "empty", roleType: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&role{}).validateRole(tt.roleType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.validateRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+			}
+		})
+	}
+}
+
+func Test_role_Create(t *testing.T) {
+	r, m := newTestRole(t)
+	expectAuthRole(m, entity.Role{ID: 2, Type: entity.RoleTypeAdmin}, nil)
+
+	// an invalid type is rejected before anything is stored
+	_, err := r.Create(authCtx(r, 42), entity.CreateRoleParam{Name: "owner", Type: "owner"})
+	assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+}
+
+func Test_role_GetListByIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int64
+		mockFunc func(m mocks)
+		want     []entity.Role
+		wantErr  bool
+	}{
+		{
+			name:     "no ids skips the query",
+			ids:      nil,
+			mockFunc: func(m mocks) {},
+			want:     []entity.Role{},
+			wantErr:  false,
+		},
+		{
+			name: "every role is loaded in one unlimited query",
+			ids:  []int64{1, 2, 3},
+			mockFunc: func(m mocks) {
+				m.role.EXPECT().GetList(gomock.Any(), entity.RoleParam{
+					IDs:         []int64{1, 2, 3},
+					QueryOption: query.Option{DisableLimit: true},
+				}).Return([]entity.Role{{ID: 1}, {ID: 3}}, nil, nil)
+			},
+			want:    []entity.Role{{ID: 1}, {ID: 3}},
+			wantErr: false,
+		},
+		{
+			name: "query error",
+			ids:  []int64{1},
+			mockFunc: func(m mocks) {
+				m.role.EXPECT().GetList(gomock.Any(), gomock.Any()).
+					Return(nil, nil, errors.NewWithCode(codes.CodeSQLRead, "connection reset"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, m := newTestRole(t)
+			tt.mockFunc(m)
+
+			// roles of already authorized objects are loaded without an authenticated user
+			got, err := r.GetListByIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("role.GetListByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,27 +2,42 @@ package usecase
 
 import (
 	"github.com/adiatma85/exp-golang-graphql/src/business/domain"
+	"github.com/adiatma85/exp-golang-graphql/src/business/usecase/role"
 	"github.com/adiatma85/exp-golang-graphql/src/business/usecase/user"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/exp-golang-graphql/utils/mailer"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
 	"github.com/adiatma85/own-go-sdk/log"
 )
 
 type Usecase struct {
 	User user.Interface
+	Role role.Interface
 }
 
 type InitParam struct {
-	Log     log.Interface
-	Dom     *domain.Domain
-	JwtAuth jwtAuth.Interface
+	Log         log.Interface
+	Dom         *domain.Domain
+	JwtAuth     jwtAuth.Interface
+	JwtAuthConf jwtAuth.Config
+	PubSub      pubsub.Interface
+	Storage     storage.Interface
+	Mailer      mailer.Interface
+	// EmailVerificationConf applies to accounts registered through the public API
+	EmailVerificationConf config.EmailVerificationConfig
 }
 
 func Init(param InitParam) *Usecase {
+	// the role usecase guards the admin functionality of the other usecases
+	roleUc := role.Init(role.InitParam{Log: param.Log, Role: param.Dom.Role, User: param.Dom.User, JwtAuth: param.JwtAuth})
+
 	usecase := &Usecase{
-		User: user.Init(user.InitParam{Log: param.Log, User: param.Dom.User, JwtAuth: param.JwtAuth}),
+		User: user.Init(user.InitParam{Log: param.Log, User: param.Dom.User, RefreshToken: param.Dom.RefreshToken, JwtAuth: param.JwtAuth, RefreshTokenExpLimit: param.JwtAuthConf.RefreshTokenExpLimit, PubSub: param.PubSub, Storage: param.Storage, VerificationToken: param.Dom.VerificationToken, Mailer: param.Mailer, EmailVerificationConf: param.EmailVerificationConf, Role: roleUc}),
+		Role: roleUc,
 		// Category: category.Init(category.InitParam{Log: param.Log, Category: param.Dom.Category, JwtAuth: param.JwtAuth}),
 		// Task:     task.Init(task.InitParam{Log: param.Log, Task: param.Dom.Task, JwtAuth: param.JwtAuth}),
-		// Role:     role.Init(role.InitParam{Log: param.Log, Role: param.Dom.Role, JwtAuth: param.JwtAuth}),
 	}
 
 	return usecase
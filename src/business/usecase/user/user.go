@@ -2,11 +2,26 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
+	refreshTokenDom "github.com/adiatma85/exp-golang-graphql/src/business/domain/refreshtoken"
 	userDom "github.com/adiatma85/exp-golang-graphql/src/business/domain/user"
+	verificationTokenDom "github.com/adiatma85/exp-golang-graphql/src/business/domain/verificationtoken"
 	"github.com/adiatma85/exp-golang-graphql/src/business/entity"
+	roleUc "github.com/adiatma85/exp-golang-graphql/src/business/usecase/role"
+	"github.com/adiatma85/exp-golang-graphql/utils/config"
+	"github.com/adiatma85/exp-golang-graphql/utils/mailer"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
+	"github.com/adiatma85/own-go-sdk/appcontext"
 	"github.com/adiatma85/own-go-sdk/codes"
 	"github.com/adiatma85/own-go-sdk/errors"
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
@@ -20,6 +35,8 @@ type Interface interface {
 	// Public Functionality
 	Get(ctx context.Context, params entity.UserParam) (entity.User, error)
 	GetList(ctx context.Context, params entity.UserParam) ([]entity.User, *entity.Pagination, error)
+	// GetListByIDs returns the active users of the ids in any order, used to resolve federated entities
+	GetListByIDs(ctx context.Context, ids []int64) ([]entity.User, error)
 
 	// Admin Functionality
 	Create(ctx context.Context, req entity.CreateUserParam) (entity.User, error)
@@ -36,33 +53,62 @@ type Interface interface {
 	SelfDelete(ctx context.Context) error
 	ChangePassword(ctx context.Context, changePasswordReq entity.ChangePasswordRequest) error
 	UpdateUserSelfProfile(ctx context.Context, updateParam entity.UpdateUserParam) error
-	RefreshToken(ctx context.Context) (entity.UserLoginResponse, error)
+	UploadAvatar(ctx context.Context, param entity.UploadAvatarParam) (entity.User, error)
+	RefreshToken(ctx context.Context, param entity.UserRefreshTokenParam) (entity.RefreshTokenResponse, error)
+	Logout(ctx context.Context, param entity.UserRefreshTokenParam) error
+	LogoutAllSessions(ctx context.Context) error
+	VerifyEmail(ctx context.Context, param entity.VerifyEmailParam) error
+	ResendVerification(ctx context.Context, param entity.ResendVerificationParam) error
 
 	// Improvement kedepannya
 	// CheckPassword(ctx context.Context, params entity.UserCheckPasswordParam, userParam entity.UserParam) (entity.HTTPMessage, error)
 	// Activate(ctx context.Context, selectParam entity.UserParam) error
-	// RefreshToken(ctx context.Context, param entity.UserRefreshTokenParam) (entity.RefreshTokenResponse, error)
 }
 
 type InitParam struct {
-	Log     log.Interface
-	User    userDom.Interface
-	JwtAuth jwtAuth.Interface
+	Log                   log.Interface
+	User                  userDom.Interface
+	RefreshToken          refreshTokenDom.Interface
+	JwtAuth               jwtAuth.Interface
+	RefreshTokenExpLimit  time.Duration
+	PubSub                pubsub.Interface
+	Storage               storage.Interface
+	VerificationToken     verificationTokenDom.Interface
+	Mailer                mailer.Interface
+	EmailVerificationConf config.EmailVerificationConfig
+	// Role guards the admin functionality
+	Role roleUc.Interface
 }
 
 type user struct {
-	log     log.Interface
-	user    userDom.Interface
-	jwtAuth jwtAuth.Interface
+	log                   log.Interface
+	user                  userDom.Interface
+	refreshToken          refreshTokenDom.Interface
+	jwtAuth               jwtAuth.Interface
+	refreshTokenExpLimit  time.Duration
+	pubsub                pubsub.Interface
+	storage               storage.Interface
+	verificationToken     verificationTokenDom.Interface
+	mailer                mailer.Interface
+	emailVerificationConf config.EmailVerificationConfig
+	role                  roleUc.Interface
 }
 
 var Now = time.Now
 
 func Init(param InitParam) Interface {
 	u := &user{
-		log:     param.Log,
-		user:    param.User,
-		jwtAuth: param.JwtAuth,
+		log:                   param.Log,
+		user:                  param.User,
+		refreshToken:          param.RefreshToken,
+		jwtAuth:               param.JwtAuth,
+		refreshTokenExpLimit:  param.RefreshTokenExpLimit,
+		pubsub:                param.PubSub,
+		storage:               param.Storage,
+		verificationToken:     param.VerificationToken,
+		mailer:                param.Mailer,
+		emailVerificationConf: param.EmailVerificationConf,
+		role:                  param.Role,
 	}
 
 	return u
@@ -73,13 +119,18 @@ func (u *user) Create(ctx context.Context, req entity.CreateUserParam) (entity.U
 	var result entity.User
 	req.ConfirmPassword = req.Password
 
-	result, err := u.validateUser(ctx, req)
+	// Only admins may create users, checked before the email so it cannot be probed
+	userInfo, err := u.role.CheckAdmin(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	// Get Auth info
-	userInfo, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	// An admin can only grant roles ranked below its own
+	if err := u.role.CheckAssignRole(ctx, req.RoleId); err != nil {
+		return result, err
+	}
+
+	result, err = u.validateUser(ctx, req)
 	if err != nil {
 		return result, err
 	}
@@ -92,12 +143,15 @@ func (u *user) Create(ctx context.Context, req entity.CreateUserParam) (entity.U
 
 	req.CreatedBy = null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID))
 	req.UpdatedBy = null.StringFrom(fmt.Sprintf("%v", userInfo.User.ID))
+	req.Status = entity.UserStatusActive
 
 	result, err = u.user.Create(ctx, req)
 	if err != nil {
 		return result, err
 	}
 
+	u.publishUser(ctx, entity.TopicUserCreated, result)
+
 	return result, nil
 }
 
@@ -116,11 +170,21 @@ func (u *user) CreateWithoutAuthInfo(ctx context.Context, req entity.CreateUserP
 		return result, err
 	}
 
+	// The account stays pending until the email is verified
+	req.Status = entity.UserStatusPending
+
 	result, err = u.user.Create(ctx, req)
 	if err != nil {
 		return result, err
 	}
 
+	// The account exists even when the email cannot be sent, resendVerification covers that
+	if err := u.sendVerification(ctx, result); err != nil {
+		u.log.Error(ctx, err)
+	}
+
+	u.publishUser(ctx, entity.TopicUserCreated, result)
+
 	return result, nil
 }
 
@@ -163,11 +227,39 @@ func (u *user) GetList(ctx context.Context, params entity.UserParam) ([]entity.U
 	return users, pg, nil
 }
 
+// GetListByIDs skips the pagination, the ids already bound the result and no count is needed
+func (u *user) GetListByIDs(ctx context.Context, ids []int64) ([]entity.User, error) {
+	if len(ids) == 0 {
+		return []entity.User{}, nil
+	}
+
+	users, _, err := u.user.GetList(ctx, entity.UserParam{
+		IDs: ids,
+		QueryOption: query.Option{
+			IsActive:     true,
+			DisableLimit: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (u *user) GetAsAdmin(ctx context.Context, params entity.UserParam) (entity.User, error) {
+	if _, err := u.role.CheckAdmin(ctx); err != nil {
+		return entity.User{}, err
+	}
+
 	return u.user.Get(ctx, params)
 }
 
 func (u *user) GetListAsAdmin(ctx context.Context, params entity.UserParam) ([]entity.User, *entity.Pagination, error) {
+	if _, err := u.role.CheckAdmin(ctx); err != nil {
+		return nil, nil, err
+	}
+
 	params.IncludePagination = true
 	users, pg, err := u.user.GetList(ctx, params)
 	if err != nil {
@@ -179,36 +271,72 @@ func (u *user) GetListAsAdmin(ctx context.Context, params entity.UserParam) ([]e
 
 // This is Admin Functionality
 func (u *user) Update(ctx context.Context, updateParam entity.UpdateUserParam, selectParam entity.UserParam) error {
-	user, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	user, err := u.role.CheckAdmin(ctx)
 	if err != nil {
 		return err
 	}
 
+	// An admin can only grant roles ranked below its own
+	if updateParam.RoleId != "" {
+		roleID, err := strconv.ParseInt(updateParam.RoleId, 10, 64)
+		if err != nil {
+			return errors.NewWithCode(codes.CodeBadRequest, "role id is not valid")
+		}
+
+		if err := u.role.CheckAssignRole(ctx, roleID); err != nil {
+			return err
+		}
+	}
+
 	updateParam.UpdatedAt = null.TimeFrom(Now())
 	updateParam.UpdatedBy = null.StringFrom(fmt.Sprintf("%v", user.User.ID))
 
-	return u.user.Update(ctx, updateParam, selectParam)
+	if err := u.user.Update(ctx, updateParam, selectParam); err != nil {
+		return err
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserUpdated, selectParam)
+
+	return nil
 }
 
 // This is Admin Functionality
 func (u *user) Delete(ctx context.Context, selectParam entity.UserParam) error {
-	user, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	user, err := u.role.CheckAdmin(ctx)
 	if err != nil {
 		return err
 	}
 
+	// the sessions of the deleted user are revoked by its id
+	if !selectParam.ID.Valid {
+		return errors.NewWithCode(codes.CodeBadRequest, "user id is required")
+	}
+
 	deleteParam := entity.UpdateUserParam{
 		Status:    null.Int64From(-1),
 		DeletedAt: null.TimeFrom(Now()),
 		DeletedBy: null.StringFrom(fmt.Sprintf("%v", user.User.ID)),
 	}
 
-	return u.user.Update(ctx, deleteParam, selectParam)
+	if err := u.user.Update(ctx, deleteParam, selectParam); err != nil {
+		return err
+	}
+
+	// A deleted account keeps no session, the same as a self deleted one
+	if err := u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		UserID: selectParam.ID,
+	}, user.User.ID); err != nil {
+		return err
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserStatusChanged, selectParam)
+
+	return nil
 }
 
 // This is Admin Functionality
 func (u *user) Activate(ctx context.Context, selectParam entity.UserParam) error {
-	user, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	user, err := u.role.CheckAdmin(ctx)
 	if err != nil {
 		return err
 	}
@@ -219,7 +347,47 @@ func (u *user) Activate(ctx context.Context, selectParam entity.UserParam) error
 		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", user.User.ID)),
 	}
 
-	return u.user.Update(ctx, activateParam, selectParam)
+	if err := u.user.Update(ctx, activateParam, selectParam); err != nil {
+		return err
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserStatusChanged, selectParam)
+
+	return nil
+}
+
+// publishUserByParam reloads the changed user regardless of its status and publishes it
+func (u *user) publishUserByParam(ctx context.Context, topic string, selectParam entity.UserParam) {
+	if !selectParam.ID.Valid {
+		return
+	}
+
+	user, err := u.user.Get(ctx, entity.UserParam{
+		ID: selectParam.ID,
+	})
+	if err != nil {
+		u.log.Error(ctx, err)
+		return
+	}
+
+	u.publishUser(ctx, topic, user)
+}
+
+// publishUser never fails the mutation, subscribers are notified on a best effort basis
+func (u *user) publishUser(ctx context.Context, topic string, user entity.User) {
+	if u.pubsub == nil {
+		return
+	}
+
+	payload, err := json.Marshal(&user)
+	if err != nil {
+		u.log.Error(ctx, errors.NewWithCode(codes.CodeMarshal, err.Error()))
+		return
+	}
+
+	if err := u.pubsub.Publish(ctx, topic, payload); err != nil {
+		u.log.Error(ctx, err)
+	}
 }
 
 func (u *user) getHashPassowrd(password string) (string, error) {
@@ -249,12 +417,9 @@ func (u *user) SignInWithPassword(ctx context.Context, req entity.UserLoginReque
 		return entity.UserLoginResponse{}, err
 	}
 
-	// validate user is exist on db and status is active
+	// validate user is exist on db and status is active or pending
 	user, err := u.user.Get(ctx, entity.UserParam{
 		Email: null.StringFrom(req.Email),
-		QueryOption: query.Option{
-			IsActive: true,
-		},
 	})
 	if err != nil {
 		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
@@ -265,19 +430,33 @@ func (u *user) SignInWithPassword(ctx context.Context, req entity.UserLoginReque
 		return entity.UserLoginResponse{}, err
 	}
 
+	if user.Status.Int64 != entity.UserStatusActive && user.Status.Int64 != entity.UserStatusPending {
+		return entity.UserLoginResponse{}, errors.NewWithCode(codes.CodeNotFound, "email not found")
+	}
+
 	// Validate the password in here
 	if !u.checkHashPassword(ctx, user.Password, req.Password) {
 		return entity.UserLoginResponse{}, errors.NewWithCode(codes.CodeUnauthorized, "credential does not match")
 	}
 
+	// Only tell the owner of the password that the email still has to be verified
+	if user.Status.Int64 == entity.UserStatusPending {
+		return entity.UserLoginResponse{}, errors.NewWithCode(codes.CodeForbidden, "email is not verified")
+	}
+
 	// Create the JWT token in here
 	accessToken, err := u.jwtAuth.CreateAccessToken(user.ConvertToAuthUser())
 	if err != nil {
 		return entity.UserLoginResponse{}, err
 	}
 
-	// Create the JWT Refresh token in here
-	refreshToken, err := u.jwtAuth.CreateRefreshToken(user.ConvertToAuthUser())
+	// Create the refresh token in here, every sign in starts a new token family
+	familyID, err := u.generateRandomString(16)
+	if err != nil {
+		return entity.UserLoginResponse{}, err
+	}
+
+	refreshToken, _, err := u.issueRefreshToken(ctx, user.ID, familyID)
 	if err != nil {
 		return entity.UserLoginResponse{}, err
 	}
@@ -310,8 +489,12 @@ func (u *user) GetSelfProfile(ctx context.Context) (entity.User, error) {
 		return entity.User{}, err
 	}
 
+	// A deactivated or deleted account can not read its profile with a token issued before
 	userParam := entity.UserParam{
 		ID: null.Int64From(user.User.ID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
 	}
 
 	return u.user.Get(ctx, userParam)
@@ -336,7 +519,20 @@ func (u *user) SelfDelete(ctx context.Context) error {
 		DeletedBy: null.StringFrom(fmt.Sprintf("%v", user.User.ID)),
 	}
 
-	return u.user.Update(ctx, deleteParam, selectParam)
+	if err := u.user.Update(ctx, deleteParam, selectParam); err != nil {
+		return err
+	}
+
+	// A deleted account keeps no session, not even one of a stolen refresh token
+	if err := u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		UserID: null.Int64From(user.User.ID),
+	}, user.User.ID); err != nil {
+		return err
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserStatusChanged, selectParam)
+
+	return nil
 }
 
 func (u *user) ChangePassword(ctx context.Context, changePasswordReq entity.ChangePasswordRequest) error {
@@ -377,7 +573,14 @@ func (u *user) ChangePassword(ctx context.Context, changePasswordReq entity.Chan
 		Password: hashedPass,
 	}
 
-	return u.user.Update(ctx, updateParam, userParam)
+	if err := u.user.Update(ctx, updateParam, userParam); err != nil {
+		return err
+	}
+
+	// Every session signed in with the old password ends, the user signs in again with the new one
+	return u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		UserID: null.Int64From(userAuth.User.ID),
+	}, userAuth.User.ID)
 }
 
 // Self Update
@@ -398,38 +601,438 @@ func (u *user) UpdateUserSelfProfile(ctx context.Context, updateParam entity.Upd
 	updateParam.UpdatedAt = null.TimeFrom(Now())
 	updateParam.UpdatedBy = null.StringFrom(fmt.Sprintf("%v", user.User.ID))
 
-	return u.user.Update(ctx, updateParam, userParam)
+	if err := u.user.Update(ctx, updateParam, userParam); err != nil {
+		return err
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserUpdated, userParam)
+
+	return nil
+}
+
+// Function to upload the avatar of the current user, the logic should be something like this
+// 1. Check the size and sniff the content type from the file itself, the client header is not trusted
+// 2. Store the file under a new key so cached urls of the previous avatar never serve the new one
+// 3. Point the user to the new key and remove the previous object on a best effort basis
+func (u *user) UploadAvatar(ctx context.Context, param entity.UploadAvatarParam) (entity.User, error) {
+	authUser, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if param.File == nil || param.Size <= 0 {
+		return entity.User{}, errors.NewWithCode(codes.CodeBadRequest, "avatar is empty")
+	}
+
+	if param.Size > entity.AvatarMaxSize {
+		return entity.User{}, errors.NewWithCode(codes.CodeBadRequest, "avatar must not exceed %d bytes", entity.AvatarMaxSize)
+	}
+
+	contentType, err := u.detectContentType(param.File)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	ext, ok := entity.AvatarContentTypes[contentType]
+	if !ok {
+		return entity.User{}, errors.NewWithCode(codes.CodeBadRequest, "avatar of type %s is not supported", contentType)
+	}
+
+	userParam := entity.UserParam{
+		ID: null.Int64From(authUser.User.ID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	}
+
+	user, err := u.user.Get(ctx, userParam)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	name, err := u.generateRandomString(16)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	key := fmt.Sprintf("avatars/%d/%s%s", user.ID, name, ext)
+	if err := u.storage.Put(ctx, storage.Object{
+		Key:         key,
+		ContentType: contentType,
+		Size:        param.Size,
+		Body:        param.File,
+	}); err != nil {
+		return entity.User{}, err
+	}
+
+	updateParam := entity.UpdateUserParam{
+		Avatar:    null.StringFrom(key),
+		UpdatedAt: null.TimeFrom(Now()),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", authUser.User.ID)),
+	}
+
+	if err := u.user.Update(ctx, updateParam, userParam); err != nil {
+		if err := u.storage.Delete(ctx, key); err != nil {
+			u.log.Error(ctx, err)
+		}
+		return entity.User{}, err
+	}
+
+	if user.Avatar.Valid && user.Avatar.String != "" {
+		if err := u.storage.Delete(ctx, user.Avatar.String); err != nil {
+			u.log.Error(ctx, err)
+		}
+	}
+
+	user, err = u.user.Get(ctx, userParam)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	u.publishUser(ctx, entity.TopicUserUpdated, user)
+
+	return user, nil
+}
+
+// detectContentType sniffs the first 512 bytes and rewinds the file for the upload
+func (u *user) detectContentType(file io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", errors.NewWithCode(codes.CodeBadRequest, "cannot read avatar: %v", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return http.DetectContentType(head[:n]), nil
 }
 
 // Function to Refresh the token, the logic should be something like this
-// 1. Validate the refresh token
-// 2. Get user auth info
-func (u *user) RefreshToken(ctx context.Context) (entity.UserLoginResponse, error) {
+// 1. Find the stored refresh token by its hash, access tokens are never stored so they are rejected
+// 2. Reusing an already rotated or revoked token revokes the whole token family
+// 3. Mark the current token as used and mint a new pair in the same family
+func (u *user) RefreshToken(ctx context.Context, param entity.UserRefreshTokenParam) (entity.RefreshTokenResponse, error) {
 	var (
-		result entity.UserLoginResponse
+		result entity.RefreshTokenResponse
 	)
 
-	jwtUser, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	if param.RefreshToken == "" {
+		return result, errors.NewWithCode(codes.CodeBadRequest, "refresh token is required")
+	}
+
+	storedToken, err := u.getStoredRefreshToken(ctx, param.RefreshToken)
+	if err != nil {
+		return result, err
+	}
+
+	// A revoked token may be a stolen one replayed after a logout, its family is revoked again
+	switch storedToken.Status {
+	case entity.RefreshTokenStatusRevoked, entity.RefreshTokenStatusUsed:
+		return result, u.revokeReusedTokenFamily(ctx, storedToken)
+	}
+
+	if !storedToken.ExpiresAt.Time.After(Now()) {
+		return result, errors.NewWithCode(codes.CodeAuthRefreshTokenExpired, "refresh token is expired")
+	}
+
+	// Only one caller can win the rotation, the loser is treated as a reuse
+	err = u.refreshToken.Update(ctx, entity.UpdateRefreshTokenParam{
+		Status:    null.Int64From(entity.RefreshTokenStatusUsed),
+		UsedAt:    null.TimeFrom(Now()),
+		UpdatedAt: null.TimeFrom(Now()),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", storedToken.UserID)),
+	}, entity.RefreshTokenParam{
+		ID: null.Int64From(storedToken.ID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLNoRowsAffected {
+			return result, u.revokeReusedTokenFamily(ctx, storedToken)
+		}
+		return result, err
+	}
+
+	user, err := u.user.Get(ctx, entity.UserParam{
+		ID: null.Int64From(storedToken.UserID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
 	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return result, errors.NewWithCode(codes.CodeInvalidRefreshToken, "refresh token owner is not found")
+		}
 		return result, err
 	}
 
 	// Generate access token in here
-	accessToken, err := u.jwtAuth.CreateAccessToken(jwtUser.User)
+	accessToken, err := u.jwtAuth.CreateAccessToken(user.ConvertToAuthUser())
 	if err != nil {
 		return result, err
 	}
 
-	// Generate refresh token in here
-	refreshToken, err := u.jwtAuth.CreateRefreshToken(jwtUser.User)
+	// Generate refresh token in here, the expiry follows JwtAuth.RefreshTokenExpLimit
+	refreshToken, expiresAt, err := u.issueRefreshToken(ctx, user.ID, storedToken.FamilyID)
 	if err != nil {
 		return result, err
 	}
 
-	result = entity.UserLoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	result = entity.RefreshTokenResponse{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: expiresAt,
 	}
 
 	return result, nil
 }
+
+// Logout revokes the token family of the given refresh token, ending that session only
+func (u *user) Logout(ctx context.Context, param entity.UserRefreshTokenParam) error {
+	userAuth, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if param.RefreshToken == "" {
+		return errors.NewWithCode(codes.CodeBadRequest, "refresh token is required")
+	}
+
+	storedToken, err := u.getStoredRefreshToken(ctx, param.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if storedToken.UserID != userAuth.User.ID {
+		return errors.NewWithCode(codes.CodeForbidden, "refresh token does not belong to the user")
+	}
+
+	return u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		FamilyID: null.StringFrom(storedToken.FamilyID),
+	}, userAuth.User.ID)
+}
+
+// LogoutAllSessions revokes every active refresh token of the user
+func (u *user) LogoutAllSessions(ctx context.Context) error {
+	userAuth, err := u.jwtAuth.GetUserAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		UserID: null.Int64From(userAuth.User.ID),
+	}, userAuth.User.ID)
+}
+
+func (u *user) issueRefreshToken(ctx context.Context, userID int64, familyID string) (string, time.Time, error) {
+	token, err := u.generateRandomString(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := Now().Add(u.refreshTokenExpLimit)
+	_, err = u.refreshToken.Create(ctx, entity.CreateRefreshTokenParam{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: entity.HashToken(token),
+		ExpiresAt: null.TimeFrom(expiresAt),
+		CreatedBy: null.StringFrom(fmt.Sprintf("%v", userID)),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+func (u *user) getStoredRefreshToken(ctx context.Context, token string) (entity.RefreshToken, error) {
+	storedToken, err := u.refreshToken.Get(ctx, entity.RefreshTokenParam{
+		TokenHash: null.StringFrom(entity.HashToken(token)),
+	})
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return storedToken, errors.NewWithCode(codes.CodeInvalidRefreshToken, "refresh token is not valid")
+		}
+		return storedToken, err
+	}
+
+	return storedToken, nil
+}
+
+func (u *user) revokeReusedTokenFamily(ctx context.Context, storedToken entity.RefreshToken) error {
+	u.log.Warn(ctx, fmt.Sprintf("refresh token reuse detected for user %v, revoking family %s", storedToken.UserID, storedToken.FamilyID))
+
+	err := u.revokeRefreshTokens(ctx, entity.RefreshTokenParam{
+		FamilyID: null.StringFrom(storedToken.FamilyID),
+	}, entity.SystemUser)
+	if err != nil {
+		return err
+	}
+
+	return errors.NewWithCode(codes.CodeInvalidRefreshToken, "refresh token is no longer valid")
+}
+
+// revokeRefreshTokens revokes every active token matching the param, having nothing left to revoke is not an error
+func (u *user) revokeRefreshTokens(ctx context.Context, selectParam entity.RefreshTokenParam, revokedBy int64) error {
+	selectParam.QueryOption.IsActive = true
+
+	err := u.refreshToken.Update(ctx, entity.UpdateRefreshTokenParam{
+		Status:    null.Int64From(entity.RefreshTokenStatusRevoked),
+		RevokedAt: null.TimeFrom(Now()),
+		UpdatedAt: null.TimeFrom(Now()),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", revokedBy)),
+	}, selectParam)
+	if err != nil && errors.GetCode(err) != codes.CodeSQLNoRowsAffected {
+		return errors.NewWithCode(codes.CodeAuthRevokeRefreshTokenFailed, err.Error())
+	}
+
+	return nil
+}
+
+// VerifyEmail activates the pending account the token was issued to, a token can be used once.
+// The account is activated before the token is used, a token left active by a failure cannot
+// activate anything since the account is no longer pending.
+func (u *user) VerifyEmail(ctx context.Context, param entity.VerifyEmailParam) error {
+	if param.Token == "" {
+		return errors.NewWithCode(codes.CodeBadRequest, "verification token is required")
+	}
+
+	now := Now()
+
+	// used, revoked and expired tokens are not found
+	storedToken, err := u.verificationToken.GetActive(ctx, entity.HashToken(param.Token), now)
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return errors.NewWithCode(codes.CodeBadRequest, "verification token is not valid")
+		}
+		return err
+	}
+
+	// Only one caller can activate the pending account
+	selectParam := entity.UserParam{
+		ID:     null.Int64From(storedToken.UserID),
+		Status: null.Int64From(entity.UserStatusPending),
+	}
+
+	err = u.user.Update(ctx, entity.UpdateUserParam{
+		Status:    null.Int64From(entity.UserStatusActive),
+		UpdatedAt: null.TimeFrom(now),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", storedToken.UserID)),
+	}, selectParam)
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLNoRowsAffected {
+			return errors.NewWithCode(codes.CodeBadRequest, "account is not pending verification")
+		}
+		return err
+	}
+
+	// The account is active by now, failing to use the token does not undo the verification
+	if err := u.verificationToken.MarkUsed(ctx, storedToken.ID, fmt.Sprintf("%v", storedToken.UserID), now); err != nil {
+		u.log.Error(ctx, err)
+	}
+
+	u.publishUserByParam(ctx, entity.TopicUserStatusChanged, selectParam)
+
+	return nil
+}
+
+// ResendVerification sends a new verification email to a pending account. Unknown emails and
+// repeated requests within the resend interval succeed without sending, so accounts cannot be probed.
+func (u *user) ResendVerification(ctx context.Context, param entity.ResendVerificationParam) error {
+	if param.Email == "" {
+		return errors.NewWithCode(codes.CodeBadRequest, "email is required")
+	}
+
+	user, err := u.user.Get(ctx, entity.UserParam{
+		Email:  null.StringFrom(param.Email),
+		Status: null.Int64From(entity.UserStatusPending),
+	})
+	if err != nil {
+		if errors.GetCode(err) == codes.CodeSQLRecordDoesNotExist {
+			return nil
+		}
+		return err
+	}
+
+	latestToken, err := u.verificationToken.Get(ctx, entity.VerificationTokenParam{
+		UserID: null.Int64From(user.ID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil && errors.GetCode(err) != codes.CodeSQLRecordDoesNotExist {
+		return err
+	}
+
+	if err == nil && latestToken.CreatedAt.Valid && Now().Sub(latestToken.CreatedAt.Time) < u.emailVerificationConf.ResendInterval {
+		u.log.Debug(ctx, fmt.Sprintf("verification email of user %v was sent recently", user.ID))
+		return nil
+	}
+
+	return u.sendVerification(ctx, user)
+}
+
+// sendVerification replaces the verification tokens of the user with a new one and emails it
+func (u *user) sendVerification(ctx context.Context, user entity.User) error {
+	err := u.verificationToken.Update(ctx, entity.UpdateVerificationTokenParam{
+		Status:    null.Int64From(entity.VerificationTokenStatusRevoked),
+		RevokedAt: null.TimeFrom(Now()),
+		UpdatedAt: null.TimeFrom(Now()),
+		UpdatedBy: null.StringFrom(fmt.Sprintf("%v", entity.SystemUser)),
+	}, entity.VerificationTokenParam{
+		UserID: null.Int64From(user.ID),
+		QueryOption: query.Option{
+			IsActive: true,
+		},
+	})
+	if err != nil && errors.GetCode(err) != codes.CodeSQLNoRowsAffected {
+		return err
+	}
+
+	token, err := u.generateRandomString(32)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := Now().Add(u.emailVerificationConf.TokenExpLimit)
+	_, err = u.verificationToken.Create(ctx, entity.CreateVerificationTokenParam{
+		UserID:    user.ID,
+		TokenHash: entity.HashToken(token),
+		ExpiresAt: null.TimeFrom(expiresAt),
+		CreatedBy: null.StringFrom(fmt.Sprintf("%v", user.ID)),
+	})
+	if err != nil {
+		return err
+	}
+
+	verifyURL, err := url.Parse(u.emailVerificationConf.URL)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, "invalid email verification url %s", u.emailVerificationConf.URL)
+	}
+	q := verifyURL.Query()
+	q.Set("token", token)
+	verifyURL.RawQuery = q.Encode()
+
+	return u.mailer.Send(ctx, mailer.Message{
+		To:       user.Email,
+		Template: mailer.TemplateVerifyEmail,
+		Language: appcontext.GetAcceptLanguage(ctx),
+		Data: map[string]interface{}{
+			"DisplayName": user.DisplayName,
+			"URL":         verifyURL.String(),
+			"ExpiresAt":   expiresAt,
+		},
+	})
+}
+
+func (u *user) generateRandomString(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"time"
 
+	"github.com/adiatma85/exp-golang-graphql/utils/mailer"
+	"github.com/adiatma85/exp-golang-graphql/utils/pubsub"
+	"github.com/adiatma85/exp-golang-graphql/utils/storage"
 	"github.com/adiatma85/own-go-sdk/instrument"
 	"github.com/adiatma85/own-go-sdk/jwtAuth"
 	"github.com/adiatma85/own-go-sdk/log"
@@ -20,6 +23,18 @@ type Application struct {
 	Instrument instrument.Config
 	Redis      redis.Config
 	JwtAuth    jwtAuth.Config
+	PubSub     pubsub.Config
+	Storage    storage.Config
+	// EmailVerification applies to accounts registered through the public API
+	EmailVerification EmailVerificationConfig
+	Mailer            mailer.Config
+}
+
+// EmailVerificationConfig, URL is the page of the web app calling verifyEmail, the token is added as ?token=
+type EmailVerificationConfig struct {
+	URL            string
+	TokenExpLimit  time.Duration
+	ResendInterval time.Duration
 }
 
 type ApplicationMeta struct {
@@ -44,6 +59,67 @@ type GinConfig struct {
 	Dummy           DummyConfig
 	Instrument      InstrumentConfig
 	Profiler        ProfilerConfig
+	GraphQL         GraphQLConfig
+}
+
+// GraphQLConfig limits the cost of a single operation, a zero value disables the limit
+type GraphQLConfig struct {
+	MaxDepth        int
+	MaxComplexity   int
+	MaxAliases      int
+	MaxDocumentSize int
+	PersistedQuery  PersistedQueryConfig
+	// TrustedDocuments only applies in release mode
+	TrustedDocuments TrustedDocumentsConfig
+	// Playground and Introspection are always on outside release mode
+	Playground    PlaygroundConfig
+	Introspection bool
+	Batch         BatchConfig
+	ResponseCache ResponseCacheConfig
+	Federation    FederationConfig
+}
+
+// BatchConfig allows a json array of operations on POST, each operation keeps its own limits.
+// MaxOperations defaults to 10 and MaxConcurrency to 1.
+type BatchConfig struct {
+	Enabled        bool
+	MaxOperations  int
+	MaxConcurrency int
+}
+
+// ResponseCacheConfig keeps whole query responses for the max age of their @cacheControl policy, lru or redis.
+// MaxAge caps the policy of any response and is also how long a mutation is remembered for invalidation.
+type ResponseCacheConfig struct {
+	Enabled bool
+	Cache   string
+	Size    int
+	MaxAge  time.Duration
+}
+
+// FederationConfig serves _service and _entities to the gateway only, it authenticates with Secret in
+// the X-Gateway-Secret header. Gateway operations are planned at runtime and skip trusted documents.
+type FederationConfig struct {
+	Enabled bool
+	Secret  string
+}
+
+type PlaygroundConfig struct {
+	Enabled   bool
+	Path      string
+	BasicAuth BasicAuthConf
+}
+
+// TrustedDocumentsConfig points to a json manifest of sha256 hash to document
+type TrustedDocumentsConfig struct {
+	Enabled      bool
+	ManifestPath string
+}
+
+// PersistedQueryConfig selects the automatic persisted query store, lru or redis
+type PersistedQueryConfig struct {
+	Cache string
+	Size  int
+	TTL   time.Duration
 }
 
 type BasicAuthConf struct {
@@ -76,6 +152,7 @@ type InstrumentConfig struct {
 	Metrics InstrumentMetricsConfig
 }
 
+// InstrumentMetricsConfig serves the instrument and the GraphQL metrics together on Path, /metrics when empty
 type InstrumentMetricsConfig struct {
 	Enabled   bool
 	Path      string
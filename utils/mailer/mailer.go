@@ -0,0 +1,143 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/mail"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/language"
+	"github.com/adiatma85/own-go-sdk/log"
+)
+
+const (
+	// Mailer Type Enum
+	TypeLog    = "log"
+	TypeSMTP   = "smtp"
+	TypeOutbox = "outbox"
+
+	// Mail Template Enum
+	TemplateVerifyEmail = "verify_email"
+
+	defaultLanguage    = language.English
+	defaultTemplateDir = "./etc/tpl/mail"
+)
+
+type Interface interface {
+	// Send delivers the message, callers decide whether a failed delivery fails their own operation
+	Send(ctx context.Context, message Message) error
+}
+
+// Message is rendered from the named template in the language of the recipient
+type Message struct {
+	To       string
+	Template string
+	Language string
+	Data     map[string]interface{}
+}
+
+// Config selects how mail leaves the service, smtp for real delivery and outbox to write every mail
+// into a maildir while developing. Both go through the queue, the log type only logs.
+type Config struct {
+	Type string
+	From string
+	// TemplateDir holds a directory of <template>.html files per language, e.g. en/verify_email.html
+	TemplateDir     string
+	DefaultLanguage string
+	SMTP            SMTPConfig
+	Outbox          OutboxConfig
+	Queue           QueueConfig
+}
+
+type InitParam struct {
+	Conf Config
+	Log  log.Interface
+}
+
+// Init returns the mailer of the given type, the log mailer is used unless one is configured
+func Init(param InitParam) Interface {
+	var t transport
+	switch param.Conf.Type {
+	case TypeSMTP:
+		t = initSMTP(param.Conf.SMTP)
+	case TypeOutbox:
+		t = initOutbox(param.Conf.Outbox, param.Log)
+	default:
+		return &logMailer{
+			log: param.Log,
+		}
+	}
+
+	from, err := mail.ParseAddress(param.Conf.From)
+	if err != nil {
+		param.Log.Fatal(context.Background(), fmt.Sprintf("[FATAL] invalid mailer from address %s", param.Conf.From))
+	}
+
+	if param.Conf.TemplateDir == "" {
+		param.Conf.TemplateDir = defaultTemplateDir
+	}
+
+	if param.Conf.DefaultLanguage == "" {
+		param.Conf.DefaultLanguage = defaultLanguage
+	}
+
+	templates, err := loadTemplates(param.Conf.TemplateDir)
+	if err != nil {
+		param.Log.Fatal(context.Background(), fmt.Sprintf("[FATAL] cannot load mail templates: %v", err))
+	}
+
+	return &mailer{
+		conf:      param.Conf,
+		log:       param.Log,
+		from:      from,
+		templates: templates,
+		queue:     initQueue(param.Conf.Queue, param.Log, t),
+	}
+}
+
+// transport delivers a rendered message to its recipients
+type transport interface {
+	deliver(ctx context.Context, from string, to []string, raw []byte) error
+}
+
+type mailer struct {
+	conf Config
+	log  log.Interface
+	from *mail.Address
+	// templates are keyed by language, then by template name
+	templates map[string]map[string]*template.Template
+	queue     *queue
+}
+
+// Send renders the message and queues it, a message that is queued is never lost to a failed delivery
+func (m *mailer) Send(ctx context.Context, message Message) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeBadRequest, "invalid recipient %q", message.To)
+	}
+
+	raw, err := m.render(message, to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return m.queue.push(queuedMail{
+		From: m.from.Address,
+		To:   []string{to.Address},
+		Raw:  raw,
+	})
+}
+
+// logMailer only logs the messages it is given, so flows sending emails can run without a mail server.
+// The data is never logged, it carries secrets like the verification link.
+type logMailer struct {
+	log log.Interface
+}
+
+func (l *logMailer) Send(ctx context.Context, message Message) error {
+	l.log.Info(ctx, fmt.Sprintf("mail %s to %s is not sent, the log mailer is configured", message.Template, message.To))
+	return nil
+}
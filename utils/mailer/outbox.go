@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+)
+
+const outboxDefaultDir = "./var/mail/outbox"
+
+// OutboxConfig writes every mail into the new directory of the maildir at Dir, mail clients can open it as is.
+// Dir is ./var/mail/outbox when empty.
+type OutboxConfig struct {
+	Dir string
+}
+
+type outbox struct {
+	conf OutboxConfig
+}
+
+func initOutbox(conf OutboxConfig, logger log.Interface) transport {
+	if conf.Dir == "" {
+		conf.Dir = outboxDefaultDir
+	}
+
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(conf.Dir, sub), 0o700); err != nil {
+			logger.Fatal(context.Background(), fmt.Sprintf("[FATAL] cannot create mail outbox %s: %v", conf.Dir, err))
+		}
+	}
+
+	return &outbox{
+		conf: conf,
+	}
+}
+
+// deliver follows the maildir protocol, the mail is written to tmp and only then moved into new
+func (o *outbox) deliver(ctx context.Context, from string, to []string, raw []byte) error {
+	unique := make([]byte, 8)
+	if _, err := rand.Read(unique); err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	name := fmt.Sprintf("%d.%s.%s", time.Now().UnixNano(), hex.EncodeToString(unique), hostname)
+	tmp := filepath.Join(o.conf.Dir, "tmp", name)
+
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := os.Rename(tmp, filepath.Join(o.conf.Dir, "new", name)); err != nil {
+		os.Remove(tmp)
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	return nil
+}
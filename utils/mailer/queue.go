@@ -0,0 +1,248 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+)
+
+const (
+	queueDefaultDir         = "./var/mail/queue"
+	queueDefaultInterval    = 10 * time.Second
+	queueDefaultBackoff     = 30 * time.Second
+	queueDefaultMaxBackoff  = time.Hour
+	queueDefaultMaxAttempts = 10
+
+	queueFailedDir = "failed"
+	queueExt       = ".json"
+)
+
+// QueueConfig, every mail is written to Dir before it is delivered and removed once it is. A failed
+// delivery is retried after Backoff, doubled on every attempt up to MaxBackoff, and moved to the
+// failed directory after MaxAttempts. Every replica needs a Dir of its own, ./var/mail/queue when empty.
+type QueueConfig struct {
+	Dir         string
+	Interval    time.Duration
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	MaxAttempts int
+}
+
+type queuedMail struct {
+	ID            string    `json:"id"`
+	From          string    `json:"from"`
+	To            []string  `json:"to"`
+	Raw           []byte    `json:"raw"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+type queue struct {
+	conf      QueueConfig
+	log       log.Interface
+	transport transport
+	// wake delivers a pushed mail right away instead of at the next interval
+	wake chan struct{}
+}
+
+func initQueue(conf QueueConfig, logger log.Interface, t transport) *queue {
+	if conf.Dir == "" {
+		conf.Dir = queueDefaultDir
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = queueDefaultInterval
+	}
+	if conf.Backoff <= 0 {
+		conf.Backoff = queueDefaultBackoff
+	}
+	if conf.MaxBackoff <= 0 {
+		conf.MaxBackoff = queueDefaultMaxBackoff
+	}
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = queueDefaultMaxAttempts
+	}
+
+	if err := os.MkdirAll(filepath.Join(conf.Dir, queueFailedDir), 0o700); err != nil {
+		logger.Fatal(context.Background(), fmt.Sprintf("[FATAL] cannot create mail queue %s: %v", conf.Dir, err))
+	}
+
+	q := &queue{
+		conf:      conf,
+		log:       logger,
+		transport: t,
+		wake:      make(chan struct{}, 1),
+	}
+
+	// mail left by a previous run is delivered as soon as the service is up again
+	go q.run()
+
+	return q
+}
+
+func (q *queue) push(mail queuedMail) error {
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	// ids sort by the time they are queued, so mail is delivered in order
+	mail.ID = fmt.Sprintf("%020d-%s", time.Now().UnixNano(), hex.EncodeToString(id))
+	mail.NextAttemptAt = time.Now()
+
+	if err := q.write(mail); err != nil {
+		return err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (q *queue) run() {
+	ticker := time.NewTicker(q.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		q.process(context.Background())
+
+		select {
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// process attempts every mail that is due, one at a time
+func (q *queue) process(ctx context.Context) {
+	entries, err := os.ReadDir(q.conf.Dir)
+	if err != nil {
+		q.log.Error(ctx, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error()))
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || filepath.Ext(entry.Name()) != queueExt {
+			continue
+		}
+
+		mail, err := q.read(entry.Name())
+		if err != nil {
+			q.log.Error(ctx, err)
+			continue
+		}
+
+		if time.Now().Before(mail.NextAttemptAt) {
+			continue
+		}
+
+		q.attempt(ctx, mail)
+	}
+}
+
+func (q *queue) attempt(ctx context.Context, mail queuedMail) {
+	err := q.transport.deliver(ctx, mail.From, mail.To, mail.Raw)
+	if err == nil {
+		if err := os.Remove(q.path(mail.ID)); err != nil {
+			q.log.Error(ctx, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error()))
+		}
+		return
+	}
+
+	mail.Attempts++
+	mail.LastError = err.Error()
+
+	if mail.Attempts >= q.conf.MaxAttempts {
+		q.log.Error(ctx, errors.NewWithCode(codes.CodeInternalServerError, "giving up on mail %s to %v after %d attempts: %v", mail.ID, mail.To, mail.Attempts, err))
+
+		if err := q.write(mail); err != nil {
+			q.log.Error(ctx, err)
+			return
+		}
+		if err := os.Rename(q.path(mail.ID), filepath.Join(q.conf.Dir, queueFailedDir, mail.ID+queueExt)); err != nil {
+			q.log.Error(ctx, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error()))
+		}
+		return
+	}
+
+	mail.NextAttemptAt = time.Now().Add(q.backoff(mail.Attempts))
+	q.log.Warn(ctx, fmt.Sprintf("mail %s to %v failed, attempt %d retries at %s: %v", mail.ID, mail.To, mail.Attempts, mail.NextAttemptAt.Format(time.RFC3339), err))
+
+	if err := q.write(mail); err != nil {
+		q.log.Error(ctx, err)
+	}
+}
+
+func (q *queue) backoff(attempts int) time.Duration {
+	backoff := q.conf.Backoff
+	for i := 1; i < attempts && backoff < q.conf.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > q.conf.MaxBackoff {
+		return q.conf.MaxBackoff
+	}
+
+	return backoff
+}
+
+func (q *queue) read(name string) (queuedMail, error) {
+	mail := queuedMail{}
+
+	raw, err := os.ReadFile(filepath.Join(q.conf.Dir, name))
+	if err != nil {
+		return mail, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := json.Unmarshal(raw, &mail); err != nil {
+		return mail, errors.NewWithCode(codes.CodeJSONUnmarshalError, "cannot read queued mail %s: %v", name, err)
+	}
+
+	return mail, nil
+}
+
+// write replaces the queued mail at once, a crash never leaves half of it behind
+func (q *queue) write(mail queuedMail) error {
+	raw, err := json.Marshal(mail)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeJSONMarshalError, err.Error())
+	}
+
+	tmp, err := os.CreateTemp(q.conf.Dir, ".mail-*")
+	if err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := os.Rename(tmp.Name(), q.path(mail.ID)); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	return nil
+}
+
+func (q *queue) path(id string) string {
+	return filepath.Join(q.conf.Dir, id+queueExt)
+}
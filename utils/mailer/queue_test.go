@@ -0,0 +1,217 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/mock/gomock"
+)
+
+// fakeTransport fails the first failures deliveries and records every delivered mail
+type fakeTransport struct {
+	mu        sync.Mutex
+	failures  int
+	attempts  int
+	delivered [][]byte
+}
+
+func (f *fakeTransport) deliver(ctx context.Context, from string, to []string, raw []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts++
+	if f.attempts <= f.failures {
+		return stderrors.New("connection refused")
+	}
+
+	f.delivered = append(f.delivered, raw)
+	return nil
+}
+
+func (f *fakeTransport) deliveredMails() [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]byte{}, f.delivered...)
+}
+
+func newTestQueue(t *testing.T, conf QueueConfig, tr transport) *queue {
+	ctrl := gomock.NewController(t)
+
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(conf.Dir, queueFailedDir), 0o700))
+
+	// the worker is left out, tests process the queue themselves
+	return &queue{
+		conf:      conf,
+		log:       logger,
+		transport: tr,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+func queuedFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+queueExt))
+	assert.NoError(t, err)
+
+	return files
+}
+
+func Test_queue_backoff(t *testing.T) {
+	q := &queue{conf: QueueConfig{Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 5, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, q.backoff(tt.attempts), "backoff after %d attempts", tt.attempts)
+	}
+}
+
+func Test_queue_attempt(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		attempts     int
+		wantQueued   int
+		wantFailed   int
+		wantAttempts int
+	}{
+		{
+			name:       "delivered mail is removed",
+			failures:   0,
+			attempts:   1,
+			wantQueued: 0,
+			wantFailed: 0,
+		},
+		{
+			name:         "failed mail stays queued for a later attempt",
+			failures:     1,
+			attempts:     1,
+			wantQueued:   1,
+			wantFailed:   0,
+			wantAttempts: 1,
+		},
+		{
+			name:         "mail is moved to failed after the last attempt",
+			failures:     3,
+			attempts:     3,
+			wantQueued:   0,
+			wantFailed:   1,
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tr := &fakeTransport{failures: tt.failures}
+			q := newTestQueue(t, QueueConfig{
+				Dir:         dir,
+				Backoff:     time.Minute,
+				MaxBackoff:  time.Hour,
+				MaxAttempts: 3,
+			}, tr)
+
+			assert.NoError(t, q.push(queuedMail{From: "no-reply@example.com", To: []string{"user@example.com"}, Raw: []byte("mail")}))
+
+			for i := 0; i < tt.attempts; i++ {
+				files := queuedFiles(t, dir)
+				if !assert.Len(t, files, 1) {
+					return
+				}
+
+				mail, err := q.read(filepath.Base(files[0]))
+				assert.NoError(t, err)
+
+				before := time.Now()
+				q.attempt(context.Background(), mail)
+
+				if i == 0 && tt.failures > 0 {
+					retried, err := q.read(filepath.Base(files[0]))
+					assert.NoError(t, err)
+					assert.Equal(t, "connection refused", retried.LastError)
+					assert.WithinDuration(t, before.Add(time.Minute), retried.NextAttemptAt, time.Second)
+				}
+			}
+
+			assert.Len(t, queuedFiles(t, dir), tt.wantQueued)
+
+			failed := queuedFiles(t, filepath.Join(dir, queueFailedDir))
+			if !assert.Len(t, failed, tt.wantFailed) {
+				return
+			}
+
+			for _, file := range append(queuedFiles(t, dir), failed...) {
+				raw, err := os.ReadFile(file)
+				assert.NoError(t, err)
+
+				mail := queuedMail{}
+				assert.NoError(t, json.Unmarshal(raw, &mail))
+				assert.Equal(t, tt.wantAttempts, mail.Attempts)
+			}
+		})
+	}
+}
+
+func Test_queue_process(t *testing.T) {
+	dir := t.TempDir()
+	tr := &fakeTransport{}
+	q := newTestQueue(t, QueueConfig{Dir: dir, Backoff: time.Minute, MaxBackoff: time.Hour, MaxAttempts: 3}, tr)
+
+	due := queuedMail{ID: "00000000000000000001-due", To: []string{"due@example.com"}, Raw: []byte("due"), NextAttemptAt: time.Now().Add(-time.Minute)}
+	later := queuedMail{ID: "00000000000000000002-later", To: []string{"later@example.com"}, Raw: []byte("later"), NextAttemptAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, q.write(due))
+	assert.NoError(t, q.write(later))
+
+	q.process(context.Background())
+
+	// only the due mail is delivered, the other one waits for its backoff
+	assert.Equal(t, [][]byte{[]byte("due")}, tr.deliveredMails())
+	assert.Equal(t, []string{q.path(later.ID)}, queuedFiles(t, dir))
+}
+
+func Test_initQueue_redeliversLeftovers(t *testing.T) {
+	dir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	logger := mock_log.NewMockInterface(ctrl)
+	logger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	logger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	// a mail queued by a previous run of the service
+	previous := &queue{conf: QueueConfig{Dir: dir}}
+	assert.NoError(t, previous.write(queuedMail{
+		ID:            "00000000000000000001-leftover",
+		To:            []string{"user@example.com"},
+		Raw:           []byte("leftover"),
+		Attempts:      2,
+		NextAttemptAt: time.Now().Add(-time.Minute),
+	}))
+
+	tr := &fakeTransport{}
+	initQueue(QueueConfig{Dir: dir, Interval: time.Hour}, logger, tr)
+
+	assert.Eventually(t, func() bool {
+		return len(tr.deliveredMails()) == 1 && len(queuedFiles(t, dir)) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []byte("leftover"), tr.deliveredMails()[0])
+}
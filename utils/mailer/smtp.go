@@ -0,0 +1,121 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+const smtpDefaultTimeout = 30 * time.Second
+
+// SMTPConfig, TLS is for servers expecting TLS from the first byte, usually on port 465. Without it
+// the connection is upgraded with STARTTLS whenever the server offers it.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLS      bool
+	Timeout  time.Duration
+}
+
+type smtpTransport struct {
+	conf SMTPConfig
+}
+
+func initSMTP(conf SMTPConfig) transport {
+	if conf.Timeout <= 0 {
+		conf.Timeout = smtpDefaultTimeout
+	}
+
+	return &smtpTransport{
+		conf: conf,
+	}
+}
+
+func (s *smtpTransport) deliver(ctx context.Context, from string, to []string, raw []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.conf.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.conf.Host, strconv.Itoa(s.conf.Port))
+	tlsConfig := &tls.Config{ServerName: s.conf.Host}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.conf.TLS {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+
+	client, err := smtp.NewClient(conn, s.conf.Host)
+	if err != nil {
+		conn.Close()
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+	defer client.Close()
+
+	if err := s.send(client, tlsConfig, from, to, raw); err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (s *smtpTransport) send(client *smtp.Client, tlsConfig *tls.Config, from string, to []string, raw []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.conf.TLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if s.conf.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.conf.Username, s.conf.Password, s.conf.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := bytes.NewReader(raw).WriteTo(w); err != nil {
+		w.Close()
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
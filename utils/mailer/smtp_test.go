@@ -0,0 +1,193 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTP answers a single session and records the commands and data it received
+type fakeSMTP struct {
+	listener   net.Listener
+	rejectRcpt string
+
+	mu       sync.Mutex
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTP(t *testing.T, rejectRcpt string) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeSMTP{listener: listener, rejectRcpt: rejectRcpt, done: make(chan struct{})}
+	go f.serve()
+
+	return f
+}
+
+func (f *fakeSMTP) conf() SMTPConfig {
+	addr := f.listener.Addr().(*net.TCPAddr)
+	return SMTPConfig{Host: addr.IP.String(), Port: addr.Port, Timeout: 5 * time.Second}
+}
+
+func (f *fakeSMTP) serve() {
+	defer close(f.done)
+
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, line)
+		f.mu.Unlock()
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch {
+		case verb == "EHLO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case verb == "AUTH":
+			tp.PrintfLine("235 authenticated")
+		case verb == "RCPT" && f.rejectRcpt != "" && strings.Contains(line, f.rejectRcpt):
+			tp.PrintfLine("550 mailbox unavailable")
+		case verb == "DATA":
+			tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.data = string(data)
+			f.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case verb == "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (f *fakeSMTP) session() ([]string, string) {
+	<-f.done
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.commands, f.data
+}
+
+func Test_smtpTransport_deliver(t *testing.T) {
+	raw := []byte("Subject: hello\r\n\r\nbody\r\n")
+
+	tests := []struct {
+		name         string
+		username     string
+		to           []string
+		rejectRcpt   string
+		wantCommands []string
+		wantData     string
+		wantErr      bool
+	}{
+		{
+			name: "mail is delivered to every recipient",
+			to:   []string{"a@example.com", "b@example.com"},
+			wantCommands: []string{
+				"EHLO localhost",
+				"MAIL FROM:<no-reply@example.com>",
+				"RCPT TO:<a@example.com>",
+				"RCPT TO:<b@example.com>",
+				"DATA",
+				"QUIT",
+			},
+			wantData: "Subject: hello\n\nbody\n",
+		},
+		{
+			name:     "authenticates when a username is configured",
+			username: "user",
+			to:       []string{"a@example.com"},
+			wantCommands: []string{
+				"EHLO localhost",
+				"AUTH PLAIN AHVzZXIAc2VjcmV0",
+				"MAIL FROM:<no-reply@example.com>",
+				"RCPT TO:<a@example.com>",
+				"DATA",
+				"QUIT",
+			},
+			wantData: "Subject: hello\n\nbody\n",
+		},
+		{
+			name:       "rejected recipient fails the delivery",
+			to:         []string{"a@example.com", "gone@example.com"},
+			rejectRcpt: "gone@example.com",
+			wantCommands: []string{
+				"EHLO localhost",
+				"MAIL FROM:<no-reply@example.com>",
+				"RCPT TO:<a@example.com>",
+				"RCPT TO:<gone@example.com>",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTP(t, tt.rejectRcpt)
+			conf := server.conf()
+			conf.Username = tt.username
+			if tt.username != "" {
+				conf.Password = "secret"
+			}
+
+			err := initSMTP(conf).deliver(context.Background(), "no-reply@example.com", tt.to, raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("smtpTransport.deliver() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, codes.CodeClientErrorOnRequest, errors.GetCode(err))
+			}
+
+			commands, data := server.session()
+			assert.Equal(t, tt.wantCommands, commands)
+			assert.Equal(t, tt.wantData, data)
+		})
+	}
+}
+
+func Test_smtpTransport_deliver_unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	err = initSMTP(SMTPConfig{Host: "127.0.0.1", Port: port, Timeout: time.Second}).
+		deliver(context.Background(), "no-reply@example.com", []string{"a@example.com"}, []byte("mail"))
+	assert.Equal(t, codes.CodeClientErrorOnRequest, errors.GetCode(err))
+}
@@ -0,0 +1,121 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"html/template"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+)
+
+// loadTemplates parses every <language>/<template>.html under dir. A template renders the body of
+// the mail and defines its subject, e.g. {{define "subject"}}Verify your email{{end}}.
+func loadTemplates(dir string) (map[string]map[string]*template.Template, error) {
+	languages, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	templates := map[string]map[string]*template.Template{}
+	for _, language := range languages {
+		if !language.IsDir() {
+			continue
+		}
+
+		files, err := filepath.Glob(filepath.Join(dir, language.Name(), "*.html"))
+		if err != nil {
+			return nil, errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+		}
+
+		templates[language.Name()] = map[string]*template.Template{}
+		for _, file := range files {
+			tpl, err := template.ParseFiles(file)
+			if err != nil {
+				return nil, errors.NewWithCode(codes.CodeFilePathOpenFailed, "cannot parse %s: %v", file, err)
+			}
+
+			if tpl.Lookup("subject") == nil {
+				return nil, errors.NewWithCode(codes.CodeFilePathOpenFailed, "%s does not define a subject", file)
+			}
+
+			templates[language.Name()][strings.TrimSuffix(filepath.Base(file), ".html")] = tpl
+		}
+	}
+
+	return templates, nil
+}
+
+// template falls back to the default language when the template is not translated
+func (m *mailer) template(name, language string) (*template.Template, error) {
+	if tpl, ok := m.templates[language][name]; ok {
+		return tpl, nil
+	}
+
+	if tpl, ok := m.templates[m.conf.DefaultLanguage][name]; ok {
+		return tpl, nil
+	}
+
+	return nil, errors.NewWithCode(codes.CodeNotImplemented, "mail template %s does not exist", name)
+}
+
+// render builds the RFC 5322 message, the html body is quoted-printable encoded
+func (m *mailer) render(message Message, to *mail.Address, now time.Time) ([]byte, error) {
+	tpl, err := m.template(message.Template, message.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := bytes.Buffer{}
+	if err := tpl.ExecuteTemplate(&subject, "subject", message.Data); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, "cannot render the subject of %s: %v", message.Template, err)
+	}
+
+	body := bytes.Buffer{}
+	if err := tpl.Execute(&body, message.Data); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, "cannot render %s: %v", message.Template, err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+	domain := m.from.Address[strings.LastIndex(m.from.Address, "@")+1:]
+
+	raw := bytes.Buffer{}
+	headers := [][2]string{
+		{"From", m.from.String()},
+		{"To", to.String()},
+		// the subject is rendered by html/template, its escaping is undone as it is not html
+		{"Subject", mime.QEncoding.Encode("utf-8", strings.TrimSpace(html.UnescapeString(subject.String())))},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `text/html; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&raw, "%s: %s\r\n", header[0], header[1])
+	}
+	raw.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&raw)
+	if _, err := qp.Write(body.Bytes()); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+	if err := qp.Close(); err != nil {
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return raw.Bytes(), nil
+}
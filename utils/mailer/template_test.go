@@ -0,0 +1,147 @@
+package mailer
+
+import (
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/language"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMailer(t *testing.T, templates map[string]string) *mailer {
+	dir := t.TempDir()
+	for name, content := range templates {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	loaded, err := loadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, err := mail.ParseAddress("GQL Blogs <no-reply@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &mailer{
+		conf:      Config{DefaultLanguage: language.English},
+		from:      from,
+		templates: loaded,
+	}
+}
+
+func Test_mailer_render(t *testing.T) {
+	m := newTestMailer(t, map[string]string{
+		"en/verify_email.html": `{{define "subject"}}Verify your email, {{.DisplayName}}{{end}}<a href="{{.URL}}">Verify</a>`,
+		"id/verify_email.html": `{{define "subject"}}Verifikasi email Anda, {{.DisplayName}}{{end}}<a href="{{.URL}}">Verifikasi</a>`,
+	})
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+	data := map[string]interface{}{
+		"DisplayName": "Budi & Siti Ä",
+		"URL":         "http://localhost:3000/verify-email?token=abc&x=1",
+	}
+
+	tests := []struct {
+		name        string
+		message     Message
+		to          string
+		wantSubject string
+		wantBody    string
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name:        "english template",
+			message:     Message{Template: TemplateVerifyEmail, Language: language.English, Data: data},
+			to:          "Budi <budi@example.com>",
+			wantSubject: "Verify your email, Budi & Siti Ä",
+			wantBody:    `<a href="http://localhost:3000/verify-email?token=abc&amp;x=1">Verify</a>`,
+		},
+		{
+			name:        "translated template",
+			message:     Message{Template: TemplateVerifyEmail, Language: language.Indonesian, Data: data},
+			to:          "budi@example.com",
+			wantSubject: "Verifikasi email Anda, Budi & Siti Ä",
+			wantBody:    `<a href="http://localhost:3000/verify-email?token=abc&amp;x=1">Verifikasi</a>`,
+		},
+		{
+			name:        "untranslated language falls back to the default language",
+			message:     Message{Template: TemplateVerifyEmail, Language: "fr", Data: data},
+			to:          "budi@example.com",
+			wantSubject: "Verify your email, Budi & Siti Ä",
+			wantBody:    `<a href="http://localhost:3000/verify-email?token=abc&amp;x=1">Verify</a>`,
+		},
+		{
+			name:     "unknown template",
+			message:  Message{Template: "unknown", Language: language.English, Data: data},
+			to:       "budi@example.com",
+			wantCode: codes.CodeNotImplemented,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to, err := mail.ParseAddress(tt.to)
+			assert.NoError(t, err)
+
+			raw, err := m.render(tt.message, to, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mailer.render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, tt.wantCode, errors.GetCode(err))
+				return
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the subject is a single encoded word header, never raw utf-8 or html escapes
+			rawSubject := msg.Header.Get("Subject")
+			assert.True(t, strings.HasPrefix(rawSubject, "=?utf-8?q?"), rawSubject)
+			subject, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, subject)
+
+			from, err := msg.Header.AddressList("From")
+			assert.NoError(t, err)
+			assert.Equal(t, []*mail.Address{m.from}, from)
+
+			recipients, err := msg.Header.AddressList("To")
+			assert.NoError(t, err)
+			assert.Equal(t, []*mail.Address{to}, recipients)
+
+			assert.Equal(t, now.Format(time.RFC1123Z), msg.Header.Get("Date"))
+			assert.True(t, strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>"))
+			assert.Equal(t, "quoted-printable", msg.Header.Get("Content-Transfer-Encoding"))
+
+			body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func Test_loadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "en"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "en", "verify_email.html"), []byte(`no subject`), 0o600))
+
+	_, err := loadTemplates(dir)
+	assert.Error(t, err, "a template without a subject is rejected")
+}
@@ -0,0 +1,197 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+	"github.com/adiatma85/own-go-sdk/redis"
+	goredis "github.com/go-redis/redis/v8"
+)
+
+const (
+	// PubSub Type Enum
+	TypeMemory = "memory"
+	TypeRedis  = "redis"
+
+	defaultBufferSize = 16
+)
+
+type Interface interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel of payloads published on the topic, it is closed once ctx is done
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+type Config struct {
+	Type       string
+	Prefix     string
+	BufferSize int
+}
+
+type InitParam struct {
+	Conf  Config
+	Log   log.Interface
+	Redis redis.Config
+}
+
+// Init returns the in-process pub/sub unless redis is configured, which is needed
+// as soon as more than one replica serves subscriptions
+func Init(param InitParam) Interface {
+	if param.Conf.BufferSize < 1 {
+		param.Conf.BufferSize = defaultBufferSize
+	}
+
+	if param.Conf.Type == TypeRedis {
+		return initRedis(param)
+	}
+
+	return &memory{
+		conf:   param.Conf,
+		log:    param.Log,
+		topics: map[string]map[chan []byte]struct{}{},
+	}
+}
+
+type memory struct {
+	conf   Config
+	log    log.Interface
+	mu     sync.RWMutex
+	topics map[string]map[chan []byte]struct{}
+}
+
+func (m *memory) Publish(ctx context.Context, topic string, payload []byte) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for ch := range m.topics[topic] {
+		select {
+		case ch <- payload:
+		default:
+			// a slow subscriber must never block the publisher
+			m.log.Warn(ctx, fmt.Sprintf("pubsub subscriber of %s is full, dropping message", topic))
+		}
+	}
+
+	return nil
+}
+
+func (m *memory) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, m.conf.BufferSize)
+
+	m.mu.Lock()
+	if m.topics[topic] == nil {
+		m.topics[topic] = map[chan []byte]struct{}{}
+	}
+	m.topics[topic][ch] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		delete(m.topics[topic], ch)
+		if len(m.topics[topic]) == 0 {
+			delete(m.topics, topic)
+		}
+		m.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+type redisPubSub struct {
+	conf   Config
+	log    log.Interface
+	client *goredis.Client
+}
+
+// initRedis connects its own client, the redis client of the sdk only exposes the key value
+// commands. It is configured from the same config and connected the same way.
+func initRedis(param InitParam) Interface {
+	opts := redisOptions(param.Redis)
+
+	client := goredis.NewClient(&opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		param.Log.Fatal(context.Background(), fmt.Sprintf("[FATAL] cannot connect to redis pubsub on address @%s, with error: %s", opts.Addr, err))
+	}
+	param.Log.Info(context.Background(), fmt.Sprintf("REDIS PUBSUB: Address @%s", opts.Addr))
+
+	return &redisPubSub{
+		conf:   param.Conf,
+		log:    param.Log,
+		client: client,
+	}
+}
+
+// redisOptions mirrors the connection options of the sdk redis client, the server name is set
+// explicitly so the certificate is verified against the configured host
+func redisOptions(conf redis.Config) goredis.Options {
+	opts := goredis.Options{
+		Network:  conf.Protocol,
+		Addr:     net.JoinHostPort(conf.Host, conf.Port),
+		Username: conf.Username,
+		Password: conf.Password,
+	}
+
+	if conf.TLS.Enabled {
+		opts.TLSConfig = &tls.Config{
+			ServerName:         conf.Host,
+			InsecureSkipVerify: conf.TLS.InsecureSkipVerify,
+		}
+	}
+
+	return opts
+}
+
+func (r *redisPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := r.client.Publish(ctx, r.conf.Prefix+topic, payload).Err(); err != nil {
+		return errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *redisPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	sub := r.client.Subscribe(ctx, r.conf.Prefix+topic)
+
+	// wait for the confirmation so no message published right after is missed
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, errors.NewWithCode(codes.CodeInternalServerError, err.Error())
+	}
+
+	ch := make(chan []byte, r.conf.BufferSize)
+
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				select {
+				case ch <- []byte(msg.Payload):
+				default:
+					r.log.Warn(ctx, fmt.Sprintf("pubsub subscriber of %s is full, dropping message", topic))
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
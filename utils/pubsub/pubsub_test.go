@@ -0,0 +1,136 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/redis"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestMemory(t *testing.T, bufferSize int) (*memory, *mock_log.MockInterface) {
+	ctrl := gomock.NewController(t)
+	logger := mock_log.NewMockInterface(ctrl)
+
+	return Init(InitParam{
+		Conf: Config{Type: TypeMemory, BufferSize: bufferSize},
+		Log:  logger,
+	}).(*memory), logger
+}
+
+// receive waits a little for a payload, nil means nothing was delivered
+func receive(ch <-chan []byte) []byte {
+	select {
+	case payload := <-ch:
+		return payload
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func Test_memory_Publish(t *testing.T) {
+	m, _ := newTestMemory(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := m.Subscribe(ctx, "user")
+	assert.NoError(t, err)
+	second, err := m.Subscribe(ctx, "user")
+	assert.NoError(t, err)
+	other, err := m.Subscribe(ctx, "role")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Publish(ctx, "user", []byte("created")))
+
+	// every subscriber of the topic gets its own copy, the others get nothing
+	assert.Equal(t, []byte("created"), receive(first))
+	assert.Equal(t, []byte("created"), receive(second))
+	assert.Nil(t, receive(other))
+
+	// a topic without subscribers is not an error
+	assert.NoError(t, m.Publish(ctx, "nobody", []byte("created")))
+}
+
+func Test_memory_Publish_fullSubscriber(t *testing.T) {
+	m, logger := newTestMemory(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	slow, err := m.Subscribe(ctx, "user")
+	assert.NoError(t, err)
+	fast, err := m.Subscribe(ctx, "user")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Publish(ctx, "user", []byte("first")))
+	assert.Equal(t, []byte("first"), receive(fast))
+
+	// the slow subscriber still holds the first message, the second one is dropped for it alone
+	logger.EXPECT().Warn(gomock.Any(), "pubsub subscriber of user is full, dropping message").Times(1)
+	done := make(chan struct{})
+	go func() {
+		_ = m.Publish(ctx, "user", []byte("second"))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish is blocked by a full subscriber")
+	}
+
+	assert.Equal(t, []byte("second"), receive(fast))
+	assert.Equal(t, []byte("first"), receive(slow))
+	assert.Nil(t, receive(slow))
+}
+
+func Test_memory_Subscribe_cancel(t *testing.T) {
+	m, _ := newTestMemory(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stay, stayCancel := context.WithCancel(context.Background())
+	defer stayCancel()
+
+	ch, err := m.Subscribe(ctx, "user")
+	assert.NoError(t, err)
+	_, err = m.Subscribe(stay, "role")
+	assert.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok, "the channel is closed once ctx is done")
+	case <-time.After(time.Second):
+		t.Fatal("the channel is never closed")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	assert.NotContains(t, m.topics, "user", "a topic without subscribers is removed")
+	assert.Len(t, m.topics["role"], 1)
+}
+
+func Test_redisOptions(t *testing.T) {
+	opts := redisOptions(redis.Config{
+		Protocol: "tcp",
+		Host:     "redis.internal",
+		Port:     "6380",
+		Username: "app",
+		Password: "secret",
+		TLS:      redis.TLSConfig{Enabled: true},
+	})
+
+	assert.Equal(t, "tcp", opts.Network)
+	assert.Equal(t, "redis.internal:6380", opts.Addr)
+	assert.Equal(t, "app", opts.Username)
+	assert.Equal(t, "secret", opts.Password)
+	if assert.NotNil(t, opts.TLSConfig) {
+		assert.Equal(t, "redis.internal", opts.TLSConfig.ServerName)
+		assert.False(t, opts.TLSConfig.InsecureSkipVerify)
+	}
+
+	opts = redisOptions(redis.Config{Host: "localhost", Port: "6379"})
+	assert.Nil(t, opts.TLSConfig)
+}
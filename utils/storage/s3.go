@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+)
+
+const (
+	s3Service        = "s3"
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3DefaultTimeout = 30 * time.Second
+)
+
+var Now = time.Now
+
+// s3 talks to the object store through its REST api signed with AWS signature version 4,
+// the body is streamed unsigned so uploads are never buffered in memory
+type s3 struct {
+	conf     S3Config
+	log      log.Interface
+	client   *http.Client
+	endpoint *url.URL
+}
+
+func initS3(param InitParam) Interface {
+	endpoint, err := url.Parse(param.Conf.S3.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		param.Log.Fatal(context.Background(), fmt.Sprintf("[FATAL] invalid s3 endpoint %s", param.Conf.S3.Endpoint))
+	}
+
+	timeout := param.Conf.S3.Timeout
+	if timeout <= 0 {
+		timeout = s3DefaultTimeout
+	}
+
+	return &s3{
+		conf:     param.Conf.S3,
+		log:      param.Log,
+		client:   &http.Client{Timeout: timeout},
+		endpoint: endpoint,
+	}
+}
+
+func (s *s3) Put(ctx context.Context, object Object) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(object.Key).String(), object.Body)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+
+	req.ContentLength = object.Size
+	req.Header.Set("Content-Type", object.ContentType)
+
+	return s.do(req)
+}
+
+func (s *s3) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+
+	return s.do(req)
+}
+
+func (s *s3) URL(key string) string {
+	if s.conf.PublicURL != "" {
+		return strings.TrimRight(s.conf.PublicURL, "/") + "/" + key
+	}
+
+	return s.objectURL(key).String()
+}
+
+func (s *s3) do(req *http.Request) error {
+	s.sign(req, Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnRequest, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *s3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.conf.UsePathStyle {
+		u.Path = "/" + s.conf.Bucket + "/" + key
+	} else {
+		u.Host = s.conf.Bucket + "." + u.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+
+	return &u
+}
+
+// sign adds the signature version 4 authorization header, see
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *s3) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedBody)
+
+	canonicalRequest, signedHeaders := s3CanonicalRequest(req)
+
+	scope := strings.Join([]string{date, s.conf.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, hashHex(canonicalRequest)}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(s3SigningKey(s.conf.SecretKey, date, s.conf.Region, s3Service), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.conf.AccessKey, scope, signedHeaders, signature))
+}
+
+// s3CanonicalRequest returns the canonical form of a request carrying the amz headers and the
+// headers it is signed with, the body is never part of it
+func s3CanonicalRequest(req *http.Request) (string, string) {
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": req.Header.Get("X-Amz-Content-Sha256"),
+		"x-amz-date":           req.Header.Get("X-Amz-Date"),
+	}
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+	}
+
+	canonicalHeaders := ""
+	for _, name := range signedHeaders {
+		canonicalHeaders += name + ":" + strings.TrimSpace(headers[name]) + "\n"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	return canonicalRequest, strings.Join(signedHeaders, ";")
+}
+
+// s3SigningKey derives the key of a single day, region and service from the secret key
+func s3SigningKey(secretKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// s3EscapePath encodes everything but the unreserved characters and the slashes
+func s3EscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashHex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
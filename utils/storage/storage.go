@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	"github.com/adiatma85/own-go-sdk/log"
+)
+
+const (
+	// Storage Type Enum
+	TypeLocal = "local"
+	TypeS3    = "s3"
+)
+
+type Interface interface {
+	Put(ctx context.Context, object Object) error
+	Delete(ctx context.Context, key string) error
+	// URL is the public address of the object, it does not check that the object exists
+	URL(key string) string
+}
+
+// Object is written under a slash separated key, e.g. avatars/1/abc.png
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+type Config struct {
+	Type  string
+	Local LocalConfig
+	S3    S3Config
+}
+
+// LocalConfig stores objects on disk, Route is where the handler serves Dir, leave it empty when a
+// web server or cdn in front of the service already does
+type LocalConfig struct {
+	Dir       string
+	Route     string
+	PublicURL string
+}
+
+// S3Config works with any S3 compatible store, path style addressing is needed by most stand-ins like MinIO
+type S3Config struct {
+	Endpoint     string
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool
+	// PublicURL defaults to the bucket url
+	PublicURL string
+	Timeout   time.Duration
+}
+
+type InitParam struct {
+	Conf Config
+	Log  log.Interface
+}
+
+func Init(param InitParam) Interface {
+	if param.Conf.Type == TypeS3 {
+		return initS3(param)
+	}
+
+	return &local{
+		conf: param.Conf.Local,
+		log:  param.Log,
+	}
+}
+
+type local struct {
+	conf LocalConfig
+	log  log.Interface
+}
+
+func (l *local) Put(ctx context.Context, object Object) error {
+	filename, err := l.path(object.Key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	// write to a temporary file first so a failed upload never leaves a partial object behind
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, object.Body); err != nil {
+		tmp.Close()
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	return nil
+}
+
+func (l *local) Delete(ctx context.Context, key string) error {
+	filename, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return errors.NewWithCode(codes.CodeFilePathOpenFailed, err.Error())
+	}
+
+	return nil
+}
+
+func (l *local) URL(key string) string {
+	return strings.TrimRight(l.conf.PublicURL, "/") + "/" + key
+}
+
+// path keeps every key inside the storage directory
+func (l *local) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		return "", errors.NewWithCode(codes.CodeBadRequest, "invalid object key %s", key)
+	}
+
+	return filepath.Join(l.conf.Dir, filepath.FromSlash(clean)), nil
+}
+
+// checkResponse turns a non 2xx answer of the object store into an error with its body
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return errors.NewWithCode(codes.CodeClientErrorOnReadBody, err.Error())
+	}
+
+	return errors.NewWithCode(codes.CodeClientErrorOnRequest, "object storage responded %d: %s", resp.StatusCode, string(body))
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adiatma85/own-go-sdk/codes"
+	"github.com/adiatma85/own-go-sdk/errors"
+	mock_log "github.com/adiatma85/own-go-sdk/tests/mock/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestLocal(t *testing.T) (*local, string) {
+	ctrl := gomock.NewController(t)
+	dir := filepath.Join(t.TempDir(), "storage")
+
+	return Init(InitParam{
+		Conf: Config{Type: TypeLocal, Local: LocalConfig{Dir: dir, PublicURL: "http://localhost:8080/storage/"}},
+		Log:  mock_log.NewMockInterface(ctrl),
+	}).(*local), dir
+}
+
+// failingReader fails after a part of the upload was read
+type failingReader struct {
+	read bool
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.read {
+		return 0, fmt.Errorf("connection reset")
+	}
+	f.read = true
+	return copy(p, "partial"), nil
+}
+
+// listFiles returns every file under dir relative to it
+func listFiles(t *testing.T, dir string) []string {
+	files := []string{}
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+
+	return files
+}
+
+func Test_local_path(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "nested key", key: "avatars/1/a.png", wantErr: false},
+		{name: "empty", key: "", wantErr: true},
+		{name: "parent directory", key: "../secret.txt", wantErr: true},
+		{name: "parent directory inside the key", key: "avatars/../../secret.txt", wantErr: true},
+		{name: "absolute", key: "/etc/passwd", wantErr: true},
+		{name: "current directory", key: "avatars/./a.png", wantErr: true},
+		{name: "double slash", key: "avatars//a.png", wantErr: true},
+		{name: "trailing slash", key: "avatars/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, dir := newTestLocal(t)
+
+			err := l.Put(context.Background(), Object{Key: tt.key, Body: strings.NewReader("data")})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("local.Put() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, []string{tt.key}, listFiles(t, dir))
+				return
+			}
+			assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+			assert.Empty(t, listFiles(t, filepath.Dir(dir)), "nothing is written anywhere")
+
+			err = l.Delete(context.Background(), tt.key)
+			assert.Equal(t, codes.CodeBadRequest, errors.GetCode(err))
+		})
+	}
+}
+
+func Test_local_Put(t *testing.T) {
+	l, dir := newTestLocal(t)
+	ctx := context.Background()
+
+	assert.NoError(t, l.Put(ctx, Object{Key: "avatars/1/a.png", Body: strings.NewReader("first")}))
+	assert.NoError(t, l.Put(ctx, Object{Key: "avatars/1/a.png", Body: strings.NewReader("second")}))
+
+	filename := filepath.Join(dir, "avatars", "1", "a.png")
+	raw, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(raw), "an object is replaced as a whole")
+
+	info, err := os.Stat(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm(), "objects are readable by the web server")
+
+	// the upload is written to a temporary file, a failure keeps the previous object and leaves nothing behind
+	err = l.Put(ctx, Object{Key: "avatars/1/a.png", Body: &failingReader{}})
+	assert.Equal(t, codes.CodeFilePathOpenFailed, errors.GetCode(err))
+
+	raw, err = os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(raw))
+	assert.Equal(t, []string{"avatars/1/a.png"}, listFiles(t, dir))
+}
+
+func Test_local_Delete(t *testing.T) {
+	l, dir := newTestLocal(t)
+	ctx := context.Background()
+
+	assert.NoError(t, l.Put(ctx, Object{Key: "avatars/1/a.png", Body: strings.NewReader("a")}))
+	assert.NoError(t, l.Put(ctx, Object{Key: "avatars/1/b.png", Body: strings.NewReader("b")}))
+
+	assert.NoError(t, l.Delete(ctx, "avatars/1/a.png"))
+	assert.Equal(t, []string{"avatars/1/b.png"}, listFiles(t, dir))
+
+	// deleting twice is not an error, the object is gone either way
+	assert.NoError(t, l.Delete(ctx, "avatars/1/a.png"))
+}
+
+func Test_local_URL(t *testing.T) {
+	l, _ := newTestLocal(t)
+
+	assert.Equal(t, "http://localhost:8080/storage/avatars/1/a.png", l.URL("avatars/1/a.png"))
+}